@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+
+	"certctl/internal/acme"
+	"certctl/internal/i18n"
+	"certctl/internal/ui"
+
+	legolog "github.com/go-acme/lego/v4/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	accountEmail   string
+	accountCA      string
+	accountCAURL   string
+	accountStaging bool
+	accountEABKID  string
+	accountEABHMAC string
+)
+
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "管理 ACME 账户",
+	Long:  "ACME 账户按 CA 和邮箱分别保存在 ~/.certctl/accounts/<CA>/<邮箱>/ 下",
+}
+
+var accountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出已保存的账户",
+	RunE:  runAccountList,
+}
+
+var accountShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "查看账户详情",
+	RunE:  runAccountShow,
+}
+
+var accountCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "在 CA 上注册新账户",
+	RunE:  runAccountCreate,
+}
+
+var accountDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "删除本地保存的账户",
+	RunE:  runAccountDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(accountCmd)
+	accountCmd.AddCommand(accountListCmd, accountShowCmd, accountCreateCmd, accountDeleteCmd)
+
+	for _, c := range []*cobra.Command{accountListCmd, accountShowCmd, accountCreateCmd, accountDeleteCmd} {
+		c.Flags().StringVar(&accountCA, "ca", "", "CA 名称")
+		c.Flags().StringVar(&accountCAURL, "ca-url", "", "自定义 ACME 目录地址")
+		c.Flags().BoolVar(&accountStaging, "staging", false, "使用测试环境（默认 Let's Encrypt，配合 --ca 时为该 CA 的测试环境）")
+	}
+	for _, c := range []*cobra.Command{accountShowCmd, accountCreateCmd, accountDeleteCmd} {
+		c.Flags().StringVarP(&accountEmail, "email", "e", "", "账户邮箱")
+	}
+	accountCreateCmd.Flags().StringVar(&accountEABKID, "eab-kid", "", "External Account Binding Key ID")
+	accountCreateCmd.Flags().StringVar(&accountEABHMAC, "eab-hmac", "", "External Account Binding HMAC Key")
+}
+
+func runAccountList(cmd *cobra.Command, args []string) error {
+	caURL := ""
+	if accountCA != "" || accountCAURL != "" || accountStaging {
+		ca, err := resolveCA(accountCA, accountCAURL, accountStaging)
+		if err != nil {
+			ui.Error(err.Error())
+			return nil
+		}
+		caURL = ca.DirectoryURL
+	}
+
+	accounts, err := acme.ListAccounts(getConfigDir(), caURL)
+	if err != nil {
+		ui.Error(fmt.Sprintf(i18n.T("account.list_fail"), err))
+		return nil
+	}
+
+	fmt.Println()
+	if len(accounts) == 0 {
+		ui.Info(i18n.T("account.none"))
+		return nil
+	}
+
+	ui.Title(i18n.T("account.title"))
+	fmt.Println()
+	for _, a := range accounts {
+		email := a.Email
+		if email == "" {
+			email = "-"
+		}
+		status := i18n.T("account.unregistered")
+		if a.Registration != nil {
+			status = i18n.T("account.registered")
+		}
+		fmt.Printf("  %s\n", email)
+		fmt.Printf("    CA: %s\n", a.CA)
+		fmt.Printf("    %s: %s\n", i18n.T("account.status"), status)
+		fmt.Println()
+	}
+	return nil
+}
+
+func runAccountShow(cmd *cobra.Command, args []string) error {
+	ca, err := resolveCA(accountCA, accountCAURL, accountStaging)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	account, err := acme.LoadAccount(getConfigDir(), ca.DirectoryURL, accountEmail)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	fmt.Println()
+	ui.StatusLine(i18n.T("detail.email"), account.Email)
+	ui.StatusLine("CA", account.CA)
+	ui.StatusLine(i18n.T("account.key_path"), account.KeyPath)
+	if account.Registration != nil {
+		ui.StatusLine(i18n.T("account.uri"), account.Registration.URI)
+		ui.StatusLine(i18n.T("account.status"), account.Registration.Body.Status)
+	} else {
+		ui.StatusLine(i18n.T("account.status"), i18n.T("account.unregistered"))
+	}
+	if account.EABKeyID != "" {
+		ui.StatusLine("EAB Key ID", account.EABKeyID)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runAccountCreate(cmd *cobra.Command, args []string) error {
+	legolog.Logger = &noopLogger{}
+
+	ca, err := resolveCA(accountCA, accountCAURL, accountStaging)
+	if err == nil {
+		ca, err = withEAB(ca, accountEABKID, accountEABHMAC)
+	}
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	configDir := getConfigDir()
+	account, err := acme.LoadOrCreateAccount(configDir, ca.DirectoryURL, accountEmail)
+	if err != nil {
+		ui.Error(fmt.Sprintf("%s: %v", i18n.T("error.account_fail"), err))
+		return nil
+	}
+	if account.Registration != nil {
+		ui.Info(fmt.Sprintf(i18n.T("account.exists"), account.Registration.URI))
+		return nil
+	}
+
+	// 注册账户不需要 DNS 验证
+	client, err := acme.NewClient(cmd.Context(), account, ca, acme.NewManualDNSProvider(nil, nil))
+	if err != nil {
+		ui.Error(fmt.Sprintf("%s: %v", i18n.T("error.client_fail"), err))
+		return nil
+	}
+
+	spin := ui.NewSpinner(i18n.T("account.registering"))
+	spin.Start()
+	err = client.Register()
+	spin.Stop()
+	if err != nil {
+		ui.Error(fmt.Sprintf("%s: %v", i18n.T("error.register_fail"), err))
+		return nil
+	}
+
+	if err := acme.SaveAccount(configDir, account); err != nil {
+		ui.Error(fmt.Sprintf(i18n.T("account.save_fail"), err))
+		return nil
+	}
+	ui.Success(fmt.Sprintf(i18n.T("account.created"), account.Registration.URI))
+	return nil
+}
+
+func runAccountDelete(cmd *cobra.Command, args []string) error {
+	ca, err := resolveCA(accountCA, accountCAURL, accountStaging)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	if !ui.Confirm(fmt.Sprintf(i18n.T("account.delete_confirm"), accountEmail, ca.DirectoryURL)) {
+		ui.Info(i18n.T("ui.cancelled_op"))
+		return nil
+	}
+
+	if err := acme.DeleteAccount(getConfigDir(), ca.DirectoryURL, accountEmail); err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+	ui.Success(i18n.T("account.deleted"))
+	return nil
+}
+
+// selectAccount 为续期自动选择账户：显式指定的邮箱 > 证书记录的邮箱 > 该 CA 下唯一的账户
+// 无法确定时返回空字符串，由调用方提示输入
+func selectAccount(configDir string, ca acme.CA, email, metaEmail string) string {
+	if email != "" {
+		return email
+	}
+	if metaEmail != "" {
+		return metaEmail
+	}
+
+	accounts, err := acme.ListAccounts(configDir, ca.DirectoryURL)
+	if err == nil && len(accounts) == 1 {
+		return accounts[0].Email
+	}
+	return ""
+}
@@ -1,17 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"certctl/internal/acme"
 	"certctl/internal/ai"
 	"certctl/internal/cert"
 	"certctl/internal/config"
 	"certctl/internal/dns"
+	"certctl/internal/hook"
 	"certctl/internal/i18n"
 	"certctl/internal/ui"
 	"certctl/pkg/domain"
@@ -23,17 +25,23 @@ import (
 )
 
 var (
-	flagDomain    string
-	flagEmail     string
-	flagOutput    string
-	flagStaging   bool
-	flagDryRun    bool
-	flagLang      string
-	flagDNS          string
-	flagAliKey       string
-	flagAliSecret    string
-	flagTencentId    string
-	flagTencentSecret string
+	flagDomains       []string
+	flagEmail         string
+	flagOutput        string
+	flagStaging       bool
+	flagDryRun        bool
+	flagLang          string
+	flagDNS           string
+	flagCA            string
+	flagCAURL         string
+	flagEABKeyID      string
+	flagEABHMAC       string
+	flagKeyType       string
+	flagDual          bool
+	flagWildcard      bool
+	flagName          string
+	flagDNSConfig     string
+	flagHooks         hook.Hooks
 )
 
 var applyCmd = &cobra.Command{
@@ -46,17 +54,27 @@ var applyCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(applyCmd)
 
-	applyCmd.Flags().StringVarP(&flagDomain, "domain", "d", "", "要申请证书的域名")
+	applyCmd.Flags().StringSliceVarP(&flagDomains, "domain", "d", nil, "要申请证书的域名，可重复指定或逗号分隔")
+	applyCmd.Flags().BoolVar(&flagWildcard, "wildcard", false, "为每个域名同时申请 *.域名 通配符")
+	applyCmd.Flags().StringVar(&flagName, "name", "", "证书名称（证书目录名），默认使用第一个域名")
 	applyCmd.Flags().StringVarP(&flagEmail, "email", "e", "", "Let's Encrypt 账户邮箱")
 	applyCmd.Flags().StringVarP(&flagOutput, "output", "o", "", "证书输出目录")
-	applyCmd.Flags().BoolVar(&flagStaging, "staging", false, "使用 Let's Encrypt 测试环境")
+	applyCmd.Flags().BoolVar(&flagStaging, "staging", false, "使用测试环境（默认 Let's Encrypt，配合 --ca 时为该 CA 的测试环境）")
+	applyCmd.Flags().StringVar(&flagCA, "ca", "", "CA 名称 (letsencrypt/zerossl/buypass/google 或自定义名称)")
+	applyCmd.Flags().StringVar(&flagCAURL, "ca-url", "", "自定义 ACME 目录地址")
+	applyCmd.Flags().StringVar(&flagEABKeyID, "eab-kid", "", "External Account Binding Key ID")
+	applyCmd.Flags().StringVar(&flagEABHMAC, "eab-hmac", "", "External Account Binding HMAC Key")
+	applyCmd.Flags().StringVar(&flagKeyType, "key-type", "", "证书私钥类型 (rsa2048/rsa3072/rsa4096/ec256/ec384，默认 rsa2048)")
+	applyCmd.Flags().BoolVar(&flagDual, "dual", false, "同时签发 ECDSA 和 RSA 两张证书")
 	applyCmd.Flags().BoolVar(&flagDryRun, "dry-run", false, "干跑模式，模拟流程不实际申请")
 	applyCmd.Flags().StringVar(&flagLang, "lang", "", "语言 (zh/en)")
-	applyCmd.Flags().StringVar(&flagDNS, "dns", "", "DNS 提供商 (aliyun/tencentcloud)")
-	applyCmd.Flags().StringVar(&flagAliKey, "ali-key", "", "阿里云 AccessKey ID")
-	applyCmd.Flags().StringVar(&flagAliSecret, "ali-secret", "", "阿里云 AccessKey Secret")
-	applyCmd.Flags().StringVar(&flagTencentId, "tencent-id", "", "腾讯云 SecretId")
-	applyCmd.Flags().StringVar(&flagTencentSecret, "tencent-secret", "", "腾讯云 SecretKey")
+	applyCmd.Flags().StringVar(&flagDNS, "dns", "", "DNS 提供商 ("+strings.Join(dns.Names(), "/")+")")
+	applyCmd.Flags().StringVar(&flagHooks.Pre, "pre-hook", "", "申请证书前执行的命令，续期时沿用")
+	applyCmd.Flags().StringVar(&flagHooks.Post, "post-hook", "", "申请结束后执行的命令（无论成功与否），续期时沿用")
+	applyCmd.Flags().StringVar(&flagHooks.Deploy, "deploy-hook", "", "证书保存后执行的命令，如 \"nginx -t && systemctl reload nginx\"，续期时沿用")
+	applyCmd.Flags().StringVar(&flagDNSConfig, "dns-config", "", "使用已保存的 DNS 配置（名称），续期时沿用")
+	addDNSFlags(applyCmd)
+	addDNSCheckFlags(applyCmd)
 }
 
 func runApply(cmd *cobra.Command, args []string) error {
@@ -64,6 +82,10 @@ func runApply(cmd *cobra.Command, args []string) error {
 	if flagLang != "" {
 		i18n.SetLang(flagLang)
 	}
+	if err := applyDNSCheckFlags(cmd); err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
 
 	// 如果没有指定输出目录，使用配置中的证书目录
 	if flagOutput == "" {
@@ -101,29 +123,41 @@ func runApply(cmd *cobra.Command, args []string) error {
 		progress.Next(i18n.T("step.prepare"))
 	}
 
-	inputDomain := flagDomain
-	if inputDomain == "" {
-		inputDomain = ui.Prompt(i18n.T("prompt.domain"))
+	inputDomains := flagDomains
+	if len(inputDomains) == 0 {
+		if input := ui.Prompt(i18n.T("prompt.domain")); input != "" {
+			inputDomains = strings.Split(input, ",")
+		}
 	}
 
-	if inputDomain == "" {
+	if len(inputDomains) == 0 {
 		ui.ErrorWithHint(i18n.T("error.domain_empty"), []string{
 			i18n.T("hint.domain_usage"),
 		})
 		return nil
 	}
 
-	domains, err := domain.GenerateWildcard(inputDomain)
+	domains, err := domain.BuildSANs(inputDomains, flagWildcard)
 	if err != nil {
 		ui.ErrorWithHint(i18n.T("error.domain_invalid"), []string{
-			fmt.Sprintf("Input: %s", inputDomain),
+			fmt.Sprintf("Input: %s", strings.Join(inputDomains, ", ")),
 			i18n.T("hint.domain_format"),
 		})
 		return nil
 	}
-	rootDomain := domains[0]
-	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.domain"), rootDomain))
-	ui.Detail(fmt.Sprintf("%s: *.%s", i18n.T("detail.wildcard"), rootDomain))
+
+	certName := flagName
+	if certName == "" {
+		certName = domain.CertName(domains)
+	}
+	if err := domain.ValidateCertName(certName); err != nil {
+		ui.ErrorWithHint(i18n.T("error.cert_name_invalid"), []string{
+			fmt.Sprintf("Name: %s", certName),
+		})
+		return nil
+	}
+	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.cert_name"), certName))
+	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.domain"), strings.Join(domains, ", ")))
 
 	email := flagEmail
 	if email == "" {
@@ -139,6 +173,33 @@ func runApply(cmd *cobra.Command, args []string) error {
 	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.email"), email))
 	ui.ProgressDone(i18n.T("progress.params_done"))
 
+	ca, err := resolveCA(flagCA, flagCAURL, flagStaging)
+	if err == nil {
+		ca, err = withEAB(ca, flagEABKeyID, flagEABHMAC)
+	}
+	if err != nil {
+		ui.ErrorWithHint(i18n.T("error.ca_invalid"), []string{
+			fmt.Sprintf("Error: %v", err),
+			i18n.T("hint.ca_list"),
+		})
+		return nil
+	}
+	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.ca"), ca.Name))
+
+	keyType, err := acme.ParseKeyType(flagKeyType)
+	if err != nil {
+		ui.ErrorWithHint(i18n.T("error.key_type_invalid"), []string{
+			fmt.Sprintf("Error: %v", err),
+		})
+		return nil
+	}
+	keyTypes := certKeyTypes(keyType, flagDual)
+	if flagDual {
+		ui.Detail(fmt.Sprintf("%s: %s + %s", i18n.T("detail.key_type"), keyTypes[0], keyTypes[1]))
+	} else {
+		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.key_type"), keyType))
+	}
+
 	// Step 2: 初始化客户端
 	if verbose && progress != nil {
 		progress.Next(i18n.T("step.init"))
@@ -147,11 +208,14 @@ func runApply(cmd *cobra.Command, args []string) error {
 	configDir := getConfigDir()
 	if verbose {
 		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.config_dir"), configDir))
-		ui.Detail(fmt.Sprintf("  ACME 服务: Let's Encrypt"))
+		ui.Detail(fmt.Sprintf("  ACME 服务: %s", ca))
+		if ca.HasEAB() {
+			ui.Detail(fmt.Sprintf("  EAB Key ID: %s", ca.EABKeyID))
+		}
 		ui.Detail(fmt.Sprintf("  账户邮箱: %s", email))
 	}
 
-	account, err := acme.LoadOrCreateAccount(configDir, email)
+	account, err := acme.LoadOrCreateAccount(configDir, ca.DirectoryURL, email)
 	if err != nil {
 		ui.ErrorWithHint(i18n.T("error.account_fail"), []string{
 			fmt.Sprintf("Error: %v", err),
@@ -167,71 +231,71 @@ func runApply(cmd *cobra.Command, args []string) error {
 		progress.Next(i18n.T("step.dns"))
 	}
 
-	var provider challenge.Provider
-
-	if flagDNS == "aliyun" {
-		aliKey := getAliKey()
-		aliSecret := getAliSecret()
-
-		if aliKey == "" || aliSecret == "" {
-			ui.ErrorWithHint(i18n.T("error.ali_key_empty"), []string{
-				i18n.T("hint.ali_key_param"),
-				i18n.T("hint.ali_key_env"),
-				i18n.T("hint.ali_key_url"),
+	// 使用已保存的 DNS 配置
+	var savedDNS *config.DNSConfig
+	if flagDNSConfig != "" {
+		p, dnsCfg, err := loadDNSConfig(flagDNSConfig)
+		if err != nil {
+			ui.ErrorWithHint(err.Error(), []string{
+				i18n.T("hint.dns_config"),
 			})
 			return nil
 		}
+		flagDNS = p.Name
+		savedDNS = &dnsCfg
+	}
 
-		if verbose {
-			ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.dns_mode"), i18n.T("detail.dns_aliyun")))
-			ui.Detail(fmt.Sprintf("  AccessKey ID: %s****", aliKey[:4]))
-			ui.Detail(fmt.Sprintf("  DNS API: dns.aliyuncs.com"))
-		}
-		aliyunProvider, err := acme.NewAliyunDNSProvider(aliKey, aliSecret, "")
-		if err != nil {
-			ui.ErrorWithHint(i18n.T("error.aliyun_fail"), []string{
-				fmt.Sprintf("Error: %v", err),
-			})
+	// 收集传播检查结果，用于详细模式展示和 AI 诊断
+	var dnsReports []*dns.Report
+	dns.SetReportHandler(func(r *dns.Report) {
+		dnsReports = append(dnsReports, r)
+	})
+	defer dns.SetReportHandler(nil)
+
+	// 取消后 ACME 客户端会中止请求并清理已添加的验证记录
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var provider challenge.Provider
+	var dnsP dns.Provider
+	var dnsCreds dns.Credentials // 自动验证使用的凭证，申请成功后提示续期时如何提供
+
+	if flagDNS != "" {
+		p, ok := dns.Get(flagDNS)
+		if !ok {
+			ui.Error(fmt.Sprintf(i18n.T("error.dns_unsupported"), flagDNS, strings.Join(dns.Names(), ", ")))
 			return nil
 		}
-		provider = aliyunProvider
-		if verbose {
-			ui.ProgressDone(i18n.T("progress.aliyun_ready"))
-		}
-	} else if flagDNS == "tencentcloud" {
-		tencentId := getTencentId()
-		tencentSecret := getTencentSecret()
-
-		if tencentId == "" || tencentSecret == "" {
-			ui.ErrorWithHint(i18n.T("error.tencentcloud_fail"), []string{
-				"请通过参数 --tencent-id 和 --tencent-secret 指定",
-				"或设置环境变量: TENCENTCLOUD_SECRET_ID, TENCENTCLOUD_SECRET_KEY",
-				"获取方式: https://console.cloud.tencent.com/cam/capi",
-			})
+
+		creds := resolveDNSCredentials(p, savedDNS)
+		promptDNSCredentials(p, creds)
+		dnsP, dnsCreds = p, creds
+		if err := p.Validate(creds); err != nil {
+			ui.ErrorWithHint(err.Error(), dnsCredentialHints(p))
 			return nil
 		}
 
 		if verbose {
-			ui.Detail(fmt.Sprintf("%s: 腾讯云自动验证", i18n.T("detail.dns_mode")))
-			ui.Detail(fmt.Sprintf("  SecretId: %s****", tencentId[:4]))
-			ui.Detail(fmt.Sprintf("  DNS API: dnspod.tencentcloudapi.com"))
+			ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.dns_mode"), fmt.Sprintf(i18n.T("detail.dns_auto"), p.DisplayName())))
+			ui.Detail(fmt.Sprintf("  %s", p.Mask(creds)))
 		}
-		tencentProvider, err := acme.NewTencentCloudDNSProvider(tencentId, tencentSecret, "")
+		dnsProvider, err := p.New(creds)
 		if err != nil {
-			ui.ErrorWithHint(i18n.T("error.tencentcloud_fail"), []string{
-				fmt.Sprintf("Error: %v", err),
-			})
+			ui.ErrorWithHint(fmt.Sprintf(i18n.T("error.dns_provider_fail"), p.DisplayName()), errorHints(err))
 			return nil
 		}
-		provider = tencentProvider
+		provider = dnsProvider
 		if verbose {
-			ui.ProgressDone(i18n.T("progress.tencentcloud_ready"))
+			ui.ProgressDone(fmt.Sprintf(i18n.T("progress.dns_ready"), p.DisplayName()))
 		}
 	} else {
 		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.dns_mode"), i18n.T("detail.dns_manual")))
 		provider = acme.NewManualDNSProvider(
-			func(c *acme.Challenge) error {
+			func(ctx context.Context, c *acme.Challenge) error {
 				fmt.Println()
+				if c.Alias != "" {
+					ui.Info(fmt.Sprintf(i18n.T("info.dns_delegated"), c.Alias, c.FQDN))
+				}
 				ui.DNSRecord(c.RecordName, "TXT", c.Value, c.FQDN)
 
 				fmt.Println()
@@ -245,12 +309,21 @@ func runApply(cmd *cobra.Command, args []string) error {
 				spin := ui.NewSpinner(i18n.T("progress.checking_dns"))
 				spin.Start()
 
-				err := dns.WaitForRecord(c.FQDN, c.Value, 5*time.Minute, func(attempt int) {
+				// 读取终端输入无法取消，手动验证只在检查 DNS 记录时捕获 Ctrl-C
+				waitCtx, stop := interruptContext(ctx)
+				err := dns.WaitForRecord(waitCtx, c.FQDN, c.Value, func(attempt int) {
 					spin.Suffix = fmt.Sprintf(" (%d)", attempt)
 				})
+				interrupted := waitCtx.Err() != nil && ctx.Err() == nil
+				stop()
 				spin.Stop()
 
+				if interrupted {
+					cancel()
+					return context.Canceled
+				}
 				if err != nil {
+					printPropagationError(err)
 					ui.ErrorWithHint(i18n.T("error.dns_fail"), []string{
 						i18n.T("hint.dns_check"),
 						i18n.T("hint.dns_wait"),
@@ -265,7 +338,14 @@ func runApply(cmd *cobra.Command, args []string) error {
 		ui.ProgressDone(i18n.T("progress.manual_ready"))
 	}
 
-	client, err := acme.NewClient(account, flagStaging, provider)
+	// 自动验证时整个申请过程都捕获 Ctrl-C
+	if flagDNS != "" {
+		var stop context.CancelFunc
+		ctx, stop = interruptContext(ctx)
+		defer stop()
+	}
+
+	client, err := acme.NewClient(ctx, account, ca, provider)
 	if err != nil {
 		ui.ErrorWithHint(i18n.T("error.client_fail"), []string{
 			fmt.Sprintf("Error: %v", err),
@@ -274,12 +354,20 @@ func runApply(cmd *cobra.Command, args []string) error {
 	}
 
 	if err := client.Register(); err != nil {
+		var interrupted *acme.InterruptedError
+		if errors.As(err, &interrupted) {
+			printInterrupted(interrupted, flagDNS == "")
+			return nil
+		}
 		errMsg := err.Error()
 		hints := []string{fmt.Sprintf("Error: %v", err)}
 		if strings.Contains(errMsg, "dial") || strings.Contains(errMsg, "timeout") {
 			hints = append(hints, i18n.T("hint.check_network"))
 			hints = append(hints, i18n.T("hint.china_blocked"))
 		}
+		if strings.Contains(errMsg, "externalAccount") || strings.Contains(errMsg, "EAB") {
+			hints = append(hints, i18n.T("hint.eab_usage"))
+		}
 		ui.ErrorWithHint(i18n.T("error.register_fail"), hints)
 		return nil
 	}
@@ -293,14 +381,9 @@ func runApply(cmd *cobra.Command, args []string) error {
 	}
 
 	if verbose {
-		if flagStaging {
-			ui.Detail(i18n.T("detail.env_staging"))
-		} else {
-			ui.Detail(i18n.T("detail.env_prod"))
-		}
-		ui.Detail(fmt.Sprintf("  主域名: %s", rootDomain))
-		ui.Detail(fmt.Sprintf("  通配符: *.%s", rootDomain))
-		ui.Detail(fmt.Sprintf("  证书类型: 通配符证书 (Wildcard)"))
+		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.ca"), ca))
+		ui.Detail(fmt.Sprintf("  证书名称: %s", certName))
+		ui.Detail(fmt.Sprintf("  域名: %s", strings.Join(domains, ", ")))
 		ui.Detail(fmt.Sprintf("  有效期: 90 天"))
 	}
 
@@ -316,25 +399,75 @@ func runApply(cmd *cobra.Command, args []string) error {
 		if verbose && progress != nil {
 			progress.Done(i18n.T("dryrun.done"))
 		}
-		ui.Detail(fmt.Sprintf("%s: %s, *.%s", i18n.T("detail.domain"), rootDomain, rootDomain))
+		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.domain"), strings.Join(domains, ", ")))
 		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.email"), email))
 		ui.Detail(fmt.Sprintf("%s: %s", i18n.T("detail.output"), flagOutput))
 		fmt.Println()
 		return nil
 	}
 
+	hookEnv := hook.Env{Domain: certName, Domains: domains}
+	if errs := runHooks(hook.StagePre, flagHooks, hookEnv); len(errs) > 0 {
+		ui.ErrorWithHint(i18n.T("hook.pre_fail"), hookHints(errs))
+		return nil
+	}
+
 	var spin *spinner.Spinner
-	if flagDNS == "aliyun" || flagDNS == "tencentcloud" {
+	if flagDNS != "" {
 		spin = ui.NewSpinner(i18n.T("progress.applying"))
 		spin.Start()
 	}
 
-	certificate, err := client.ObtainCertificate(domains)
+	certificates, err := obtainCertificates(client, domains, keyTypes)
 
 	if spin != nil {
 		spin.Stop()
 	}
 
+	// 记录申请参数，续期时使用同一个 CA；只记录用户选择或保存的 DNS 配置
+	saveMeta := func() {
+		if err := cert.SaveMeta(flagOutput, certName, &cert.Meta{
+			Name:      certName,
+			Domains:   domains,
+			CA:        ca.Name,
+			CAURL:     ca.DirectoryURL,
+			Email:     email,
+			KeyType:   string(keyType),
+			Dual:      flagDual,
+			DNS:       flagDNS,
+			DNSConfig: flagDNSConfig,
+			Hooks:     flagHooks,
+		}); err != nil {
+			ui.Warning(fmt.Sprintf(i18n.T("error.meta_save"), err))
+		}
+	}
+
+	// 双证书模式下后一张证书失败时，已签发的证书照常保存，不运行 deploy 钩子
+	if err != nil {
+		if partial := savePartial(flagOutput, certName, certificates, keyTypes, flagDual, err); partial != nil {
+			saveMeta()
+			printPartialIssue(partial)
+		}
+	}
+
+	// 自动验证时由 lego 在后台检查传播，详细模式下展示每次检查结果
+	if verbose && flagDNS != "" {
+		for _, r := range dnsReports {
+			printPropagationReport(r)
+		}
+	}
+
+	for _, hookErr := range runHooks(hook.StagePost, flagHooks, hookEnv) {
+		ui.Warning(fmt.Sprintf(i18n.T("hook.fail"), hookErr))
+	}
+
+	var interrupted *acme.InterruptedError
+	if errors.As(err, &interrupted) {
+		printInterrupted(interrupted, flagDNS == "")
+		fmt.Println()
+		return nil
+	}
+
 	if err != nil {
 		errMsg := err.Error()
 
@@ -349,7 +482,7 @@ func runApply(cmd *cobra.Command, args []string) error {
 			fmt.Println()
 			spin := ui.NewSpinner(i18n.T("ui.ai_diagnosing"))
 			spin.Start()
-			diagnosis, aiErr := ai.DiagnoseError(errMsg, rootDomain, flagDNS)
+			diagnosis, aiErr := ai.DiagnoseError(errMsg, strings.Join(domains, ", "), flagDNS, failedPropagation(dnsReports))
 			spin.Stop()
 			if aiErr != nil {
 				ui.Info(fmt.Sprintf("AI 诊断失败: %v", aiErr))
@@ -363,7 +496,7 @@ func runApply(cmd *cobra.Command, args []string) error {
 			return nil
 		} else {
 			// AI 未启用，使用内置提示
-			hints := []string{fmt.Sprintf("Error: %v", err)}
+			hints := errorHints(err)
 			if strings.Contains(errMsg, "DNS") || strings.Contains(errMsg, "TXT") {
 				hints = append(hints, i18n.T("hint.dns_check"))
 			}
@@ -383,7 +516,7 @@ func runApply(cmd *cobra.Command, args []string) error {
 		progress.Next(i18n.T("step.save"))
 	}
 
-	certPath, keyPath, err := cert.Save(flagOutput, rootDomain, certificate.Certificate, certificate.PrivateKey)
+	saved, err := saveCertificates(flagOutput, certName, certificates, flagDual)
 	if err != nil {
 		ui.ErrorWithHint(i18n.T("error.save_fail"), []string{
 			fmt.Sprintf("Error: %v", err),
@@ -391,41 +524,32 @@ func runApply(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	absOut, _ := filepath.Abs(flagOutput)
-	certPath = filepath.Join(absOut, rootDomain, rootDomain+".pem")
-	keyPath = filepath.Join(absOut, rootDomain, rootDomain+".key")
-
 	ui.ProgressDone(i18n.T("progress.saved"))
 
+	// 命令行参数或交互输入的凭证不会自动保存，提示续期时如何提供
+	if flagDNS != "" {
+		printRenewDNSHints(dnsP, dnsCreds, flagDNSConfig)
+	}
+	saveMeta()
+
+	// 钩子失败只提示，新证书已保存
+	for _, hookErr := range runDeployHooks(certName, domains, flagHooks, saved) {
+		ui.Warning(fmt.Sprintf(i18n.T("hook.fail"), hookErr))
+	}
+
 	// 完成
 	if verbose && progress != nil {
 		progress.Done(i18n.T("progress.cert_ok"))
 	}
-	ui.CertResult(certPath, keyPath, certificate.NotAfter.Format("2006-01-02"))
+	for _, c := range saved {
+		ui.CertResult(c.CertPath, c.KeyPath, c.NotAfter.Format("2006-01-02"))
+	}
 	fmt.Println()
 
 	return nil
 }
 
-func getAliKey() string {
-	if flagAliKey != "" {
-		return flagAliKey
-	}
-	if key := os.Getenv("ALICLOUD_ACCESS_KEY"); key != "" {
-		return key
-	}
-	return ui.Prompt(i18n.T("prompt.ali_key"))
-}
 
-func getAliSecret() string {
-	if flagAliSecret != "" {
-		return flagAliSecret
-	}
-	if secret := os.Getenv("ALICLOUD_SECRET_KEY"); secret != "" {
-		return secret
-	}
-	return ui.PromptSecret(i18n.T("prompt.ali_secret"))
-}
 
 func getConfigDir() string {
 	home, err := os.UserHomeDir()
@@ -435,22 +559,5 @@ func getConfigDir() string {
 	return filepath.Join(home, ".certctl")
 }
 
-func getTencentId() string {
-	if flagTencentId  != "" {
-		return flagTencentId
-	}
-	if id := os.Getenv("TENCENTCLOUD_SECRET_ID"); id != "" {
-		return id
-	}
-	return ui.Prompt("腾讯云 SecretId")
-}
 
-func getTencentSecret() string {
-	if flagTencentSecret != "" {
-		return flagTencentSecret
-	}
-	if secret := os.Getenv("TENCENTCLOUD_SECRET_KEY"); secret != "" {
-		return secret
-	}
-	return ui.PromptSecret("腾讯云 SecretKey")
-}
+
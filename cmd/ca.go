@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"certctl/internal/acme"
+	"certctl/internal/config"
+	"certctl/internal/i18n"
+	"certctl/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var caCmd = &cobra.Command{
+	Use:   "ca",
+	Short: "管理 ACME CA",
+	Long:  "查看内置 CA，添加或删除自定义 CA（如 ZeroSSL、内部 step-ca、Pebble）",
+}
+
+var caListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出可用的 CA",
+	RunE:  runCAList,
+}
+
+var caAddCmd = &cobra.Command{
+	Use:   "add <name> <directory-url>",
+	Short: "添加自定义 CA",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runCAAdd,
+}
+
+var caDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "删除自定义 CA",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runCADelete,
+}
+
+var (
+	caAddEABKeyID string
+	caAddEABHMAC  string
+)
+
+func init() {
+	rootCmd.AddCommand(caCmd)
+	caCmd.AddCommand(caListCmd, caAddCmd, caDeleteCmd)
+
+	caAddCmd.Flags().StringVar(&caAddEABKeyID, "eab-kid", "", "External Account Binding Key ID")
+	caAddCmd.Flags().StringVar(&caAddEABHMAC, "eab-hmac", "", "External Account Binding HMAC Key")
+}
+
+func runCAList(cmd *cobra.Command, args []string) error {
+	ui.Title(i18n.T("ca.builtin"))
+	for _, name := range acme.KnownCANames() {
+		ui.StatusLine(name, acme.KnownCAs[name])
+	}
+
+	ui.Title(i18n.T("ca.custom"))
+	cas := config.GetCAConfigs()
+	if len(cas) == 0 {
+		ui.Info(i18n.T("ui.not_configured"))
+	}
+	for _, ca := range cas {
+		if ca.EABKeyID != "" {
+			ui.StatusLine(ca.Name, fmt.Sprintf("%s (EAB: %s)", ca.DirectoryURL, ca.EABKeyID))
+		} else {
+			ui.StatusLine(ca.Name, ca.DirectoryURL)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runCAAdd(cmd *cobra.Command, args []string) error {
+	name, dirURL := args[0], args[1]
+	if _, ok := acme.LookupCA(name); ok {
+		ui.Error(fmt.Sprintf(i18n.T("ca.name_builtin"), name))
+		return nil
+	}
+	if u, err := url.Parse(dirURL); err != nil || u.Scheme == "" || u.Host == "" {
+		ui.Error(fmt.Sprintf(i18n.T("ca.url_invalid"), dirURL))
+		return nil
+	}
+
+	if (caAddEABKeyID == "") != (caAddEABHMAC == "") {
+		ui.Error(i18n.T("error.eab_incomplete"))
+		return nil
+	}
+
+	config.AddCAConfig(name, dirURL, caAddEABKeyID, caAddEABHMAC)
+	ui.Success(fmt.Sprintf(i18n.T("ca.saved"), name))
+	return nil
+}
+
+func runCADelete(cmd *cobra.Command, args []string) error {
+	if _, ok := config.GetCAConfigByName(args[0]); !ok {
+		ui.Error(fmt.Sprintf(i18n.T("ca.not_found"), args[0]))
+		return nil
+	}
+	config.DeleteCAConfig(args[0])
+	ui.Success(fmt.Sprintf(i18n.T("ui.deleted"), args[0]))
+	return nil
+}
+
+// resolveCA 根据 --ca / --ca-url / --staging 解析要使用的 CA
+// 优先级：--ca-url > --ca（自定义配置优先于内置）> --staging > 默认 Let's Encrypt
+// --staging 与 --ca 同时使用时换成该 CA 的内置测试环境，没有测试环境的 CA 和 --ca-url 直接报错，避免误签正式证书
+func resolveCA(name, dirURL string, staging bool) (acme.CA, error) {
+	if staging && dirURL != "" {
+		return acme.CA{}, fmt.Errorf(i18n.T("ca.staging_url"))
+	}
+	if staging && name != "" && !strings.HasSuffix(name, "-staging") {
+		if _, ok := config.GetCAConfigByName(name); ok {
+			return acme.CA{}, fmt.Errorf(i18n.T("ca.no_staging"), name)
+		}
+		if _, ok := acme.LookupCA(name); !ok {
+			return acme.CA{}, fmt.Errorf(i18n.T("ca.not_found"), name)
+		}
+		if _, ok := acme.LookupCA(name + "-staging"); !ok {
+			return acme.CA{}, fmt.Errorf(i18n.T("ca.no_staging"), name)
+		}
+		name += "-staging"
+	}
+
+	if dirURL != "" {
+		if name == "" {
+			name = "custom"
+		}
+		return acme.CA{Name: name, DirectoryURL: dirURL}, nil
+	}
+
+	if name != "" {
+		if ca, ok := config.GetCAConfigByName(name); ok {
+			return acme.CA{
+				Name:         ca.Name,
+				DirectoryURL: ca.DirectoryURL,
+				EABKeyID:     ca.EABKeyID,
+				EABHMACKey:   ca.EABHMACKey,
+			}, nil
+		}
+		if ca, ok := acme.LookupCA(name); ok {
+			return ca, nil
+		}
+		return acme.CA{}, fmt.Errorf(i18n.T("ca.not_found"), name)
+	}
+
+	if staging {
+		ca, _ := acme.LookupCA("letsencrypt-staging")
+		return ca, nil
+	}
+
+	ca, _ := acme.LookupCA(acme.DefaultCAName)
+	return ca, nil
+}
+
+// withEAB 使用命令行参数覆盖 CA 的 EAB 凭证
+func withEAB(ca acme.CA, keyID, hmacKey string) (acme.CA, error) {
+	if keyID == "" && hmacKey == "" {
+		return ca, nil
+	}
+	if keyID == "" || hmacKey == "" {
+		return ca, fmt.Errorf(i18n.T("error.eab_incomplete"))
+	}
+	ca.EABKeyID = keyID
+	ca.EABHMACKey = hmacKey
+	return ca, nil
+}
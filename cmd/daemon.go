@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"certctl/internal/acme"
+	"certctl/internal/config"
+	"certctl/internal/dns"
+	"certctl/internal/i18n"
+
+	legolog "github.com/go-acme/lego/v4/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval time.Duration
+	daemonJitter   time.Duration
+	daemonDays     int
+	daemonOutput   string
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "常驻后台，定期自动续期证书",
+	Long: `常驻运行，按固定间隔扫描证书目录，续期即将到期且使用自动 DNS 验证的证书
+
+每次检查前会随机等待一段时间（--jitter），避免多台主机同时请求 CA。
+收到 SIGTERM / SIGINT 时中止当前续期、清理已添加的验证记录后退出，收到 SIGHUP 时重新加载配置。`,
+	RunE: runDaemon,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", 12*time.Hour, "检查间隔")
+	daemonCmd.Flags().DurationVar(&daemonJitter, "jitter", time.Hour, "每次检查前的最大随机延迟，0 表示不延迟")
+	daemonCmd.Flags().IntVar(&daemonDays, "days", 30, "剩余天数少于该值的证书才会续期")
+	daemonCmd.Flags().StringVarP(&daemonOutput, "output", "o", "", "证书目录（默认使用配置中的证书目录）")
+	addDNSCheckFlags(daemonCmd)
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	legolog.Logger = &noopLogger{}
+	cmd.SilenceUsage = true
+
+	if daemonInterval <= 0 {
+		return fmt.Errorf(i18n.T("daemon.bad_interval"), daemonInterval)
+	}
+	if err := applyDNSCheckFlags(cmd); err != nil {
+		return err
+	}
+
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	logger.Printf(i18n.T("daemon.started"), daemonInterval, daemonJitter, daemonDays)
+
+	// 启动后立即进行第一次检查（同样带随机延迟）
+	next := time.Duration(0)
+	for {
+		delay := next
+		if daemonJitter > 0 {
+			delay += time.Duration(rng.Int63n(int64(daemonJitter)))
+		}
+		timer := time.NewTimer(delay)
+
+	wait:
+		for {
+			select {
+			case <-timer.C:
+				break wait
+			case sig := <-sigs:
+				if sig == syscall.SIGHUP {
+					reloadDaemonConfig(cmd, logger)
+					continue
+				}
+				timer.Stop()
+				logger.Printf(i18n.T("daemon.stopping"), sig)
+				return nil
+			}
+		}
+
+		// 续期过程中收到退出信号时取消续期，等待验证记录清理完成后退出；
+		// SIGHUP 在本轮结束后处理，避免续期中途更换传播检查设置
+		done := make(chan struct{})
+		go func() {
+			daemonCheck(ctx, logger)
+			close(done)
+		}()
+
+		reload := false
+	renewing:
+		for {
+			select {
+			case <-done:
+				break renewing
+			case sig := <-sigs:
+				if sig == syscall.SIGHUP {
+					reload = true
+					continue
+				}
+				logger.Printf(i18n.T("daemon.stopping"), sig)
+				cancel()
+				<-done
+				return nil
+			}
+		}
+
+		next = daemonInterval
+		logger.Printf(i18n.T("daemon.next"), daemonInterval)
+		if reload {
+			reloadDaemonConfig(cmd, logger)
+		}
+	}
+}
+
+// daemonCheck 执行一轮检查并记录每个证书的续期结果，ctx 取消时中止续期
+func daemonCheck(ctx context.Context, logger *log.Logger) {
+	outputDir := daemonOutput
+	if outputDir == "" {
+		outputDir = config.Get().CertsDir
+	}
+
+	logger.Printf(i18n.T("daemon.checking"), outputDir)
+	results, err := renewDue(ctx, outputDir, daemonDays, func(name string) {
+		logger.Printf(i18n.T("daemon.renewing"), name)
+	})
+	if err != nil {
+		logger.Printf(i18n.T("daemon.scan_fail"), err)
+		return
+	}
+
+	var renewed, failed, cancelled int
+	for _, r := range results {
+		for _, hookErr := range r.HookErrs {
+			logger.Printf(i18n.T("hook.fail"), hookErr)
+		}
+		switch r.Status {
+		case renewStatusRenewed:
+			renewed++
+			logger.Printf(i18n.T("daemon.renewed"), r.Name, r.NotAfter.Format("2006-01-02"))
+		case renewStatusFailed:
+			failed++
+			logger.Printf(i18n.T("daemon.failed"), r.Name, r.Err)
+			var interrupted *acme.InterruptedError
+			if errors.As(r.Err, &interrupted) {
+				for _, cleanupErr := range interrupted.Failed {
+					logger.Printf(i18n.T("daemon.cleanup_fail"), cleanupErr)
+				}
+			}
+		case renewStatusCancelled:
+			cancelled++
+			logger.Printf(i18n.T("daemon.cancelled"), r.Name)
+		}
+	}
+	logger.Printf(i18n.T("daemon.summary"), len(results), renewed, failed, cancelled)
+}
+
+// reloadDaemonConfig 重新加载配置，已保存的 DNS 配置、CA 和证书目录在下一轮检查生效
+func reloadDaemonConfig(cmd *cobra.Command, logger *log.Logger) {
+	cfg := config.Reload()
+	i18n.SetLang(cfg.Language)
+	if err := applyDNSCheckFlags(cmd); err != nil {
+		logger.Print(err)
+	}
+	dns.ResetZoneCache()
+	logger.Print(i18n.T("daemon.reloaded"))
+}
@@ -0,0 +1,499 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"certctl/internal/config"
+	"certctl/internal/dns"
+	"certctl/internal/i18n"
+	"certctl/internal/ui"
+	"certctl/pkg/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	delegateDomains []string
+	delegateZone    string
+	delegateCheck   bool
+)
+
+var dnsCmd = &cobra.Command{
+	Use:   "dns",
+	Short: "DNS 验证相关工具",
+}
+
+var dnsDelegateCmd = &cobra.Command{
+	Use:   "delegate",
+	Short: "生成 CNAME 委托记录",
+	Long: `将 _acme-challenge 验证记录通过 CNAME 委托到可自动管理的 zone，
+输出需要在域名原 DNS 服务商处添加的 CNAME 记录。
+添加后申请证书时会跟随 CNAME，将 TXT 记录写入委托目标。`,
+	Example: `  certctl dns delegate -d example.com -d www.example.com --zone acme.example.net
+  certctl dns delegate -d example.com --zone acme.example.net --check`,
+	RunE: runDNSDelegate,
+}
+
+func init() {
+	rootCmd.AddCommand(dnsCmd)
+	dnsCmd.AddCommand(dnsDelegateCmd)
+
+	dnsDelegateCmd.Flags().StringSliceVarP(&delegateDomains, "domain", "d", nil, "需要委托验证的域名，可重复指定或逗号分隔")
+	dnsDelegateCmd.Flags().StringVar(&delegateZone, "zone", "", "可自动管理的委托 zone，如 acme.example.net")
+	dnsDelegateCmd.Flags().BoolVar(&delegateCheck, "check", false, "查询 CNAME 记录是否已生效")
+}
+
+func runDNSDelegate(cmd *cobra.Command, args []string) error {
+	fmt.Println()
+	if len(delegateDomains) == 0 || delegateZone == "" {
+		ui.ErrorWithHint(i18n.T("delegate.usage"), []string{
+			"certctl dns delegate -d example.com --zone acme.example.net",
+		})
+		return nil
+	}
+
+	zone, err := domain.Normalize(delegateZone)
+	if err != nil {
+		ui.Error(fmt.Sprintf("%s: %s", i18n.T("error.domain_invalid"), delegateZone))
+		return nil
+	}
+
+	headers := []string{i18n.T("delegate.name"), i18n.T("delegate.type"), i18n.T("delegate.target")}
+	if delegateCheck {
+		headers = append(headers, i18n.T("delegate.status"))
+	}
+
+	// 通配符与根域名共用同一验证记录，只需委托一次
+	seen := map[string]bool{}
+	var rows [][]string
+	for _, d := range delegateDomains {
+		name, err := domain.Normalize(d)
+		if err != nil {
+			ui.Error(fmt.Sprintf("%s: %s", i18n.T("error.domain_invalid"), d))
+			return nil
+		}
+		record := dns.ChallengeFQDN(name)
+		if seen[record] {
+			continue
+		}
+		seen[record] = true
+
+		target := dns.DelegationTarget(name, zone)
+		row := []string{record, "CNAME", target}
+		if delegateCheck {
+			row = append(row, delegationStatus(cmd.Context(), record, target))
+		}
+		rows = append(rows, row)
+	}
+
+	ui.Info(i18n.T("delegate.intro"))
+	fmt.Println()
+	ui.Table(headers, rows)
+	fmt.Println()
+	ui.Detail(fmt.Sprintf(i18n.T("delegate.next"), zone))
+	fmt.Println()
+	return nil
+}
+
+// delegationStatus 检查验证记录是否已委托到 target，target 本身可以继续 CNAME 到其他记录
+func delegationStatus(ctx context.Context, name, target string) string {
+	resolved := dns.ResolveCNAME(ctx, name)
+	switch {
+	case resolved == name:
+		return i18n.T("delegate.missing")
+	case resolved == target || resolved == dns.ResolveCNAME(ctx, target):
+		return i18n.T("delegate.ok")
+	default:
+		return fmt.Sprintf(i18n.T("delegate.other"), resolved)
+	}
+}
+
+// dnsFlags 由提供商注册信息生成的凭证参数：提供商 → 字段 → 参数值
+var dnsFlags = map[string]map[string]*string{}
+
+// addDNSFlags 为所有提供商的凭证字段注册命令行参数，多个命令共用同一组参数值
+func addDNSFlags(c *cobra.Command) {
+	for _, p := range dns.Providers() {
+		values, ok := dnsFlags[p.Name]
+		if !ok {
+			values = map[string]*string{}
+			dnsFlags[p.Name] = values
+		}
+		for _, f := range p.Fields {
+			if values[f.Key] == nil {
+				values[f.Key] = new(string)
+			}
+			c.Flags().StringVar(values[f.Key], f.Flag, "", p.DisplayName()+" "+f.Label)
+		}
+	}
+}
+
+// DNS 传播检查参数，未指定时使用配置文件中的 propagation 设置
+var (
+	flagDNSResolvers      []string
+	flagDNSTimeout        time.Duration
+	flagDNSInterval       time.Duration
+	flagDNSGraceDelay     time.Duration
+	flagDNSRecursiveCheck bool
+)
+
+// addDNSCheckFlags 注册 DNS 传播检查参数
+func addDNSCheckFlags(c *cobra.Command) {
+	c.Flags().StringSliceVar(&flagDNSResolvers, "dns-resolvers", nil, "检查 DNS 记录使用的递归解析器，如 10.0.0.53:53、tls://1.1.1.1、https://dns.google/dns-query（默认 8.8.8.8、1.1.1.1、223.5.5.5）")
+	c.Flags().DurationVar(&flagDNSTimeout, "dns-timeout", dns.DefaultCheckTimeout, "等待 DNS 记录生效的超时时间")
+	c.Flags().DurationVar(&flagDNSInterval, "dns-interval", dns.DefaultCheckInterval, "DNS 记录首次检查间隔，之后逐次翻倍（最长 1 分钟）")
+	c.Flags().DurationVar(&flagDNSGraceDelay, "dns-grace", 0, "DNS 记录生效后、通知 CA 验证前的等待时间")
+	c.Flags().BoolVar(&flagDNSRecursiveCheck, "dns-recursive-check", false, "权威服务器生效后，再确认递归解析器也能查到 TXT 记录")
+}
+
+// applyDNSCheckFlags 合并参数和配置文件中的传播检查设置，参数优先
+// 交互模式下 c 为 nil，只使用配置文件
+func applyDNSCheckFlags(c *cobra.Command) error {
+	changed := func(name string) bool {
+		return c != nil && c.Flags().Changed(name)
+	}
+	cfg := config.Get().Propagation
+	opts := dns.CheckOptions{Recursive: cfg.Recursive}
+
+	resolvers := cfg.Resolvers
+	if changed("dns-resolvers") {
+		resolvers = flagDNSResolvers
+	}
+	for _, r := range resolvers {
+		addr, err := dns.NormalizeResolver(r)
+		if err != nil {
+			return err
+		}
+		opts.Resolvers = append(opts.Resolvers, addr)
+	}
+
+	durations := []struct {
+		flag  string
+		value time.Duration
+		cfg   string
+		dst   *time.Duration
+	}{
+		{"dns-timeout", flagDNSTimeout, cfg.Timeout, &opts.Timeout},
+		{"dns-interval", flagDNSInterval, cfg.Interval, &opts.Interval},
+		{"dns-grace", flagDNSGraceDelay, cfg.GraceDelay, &opts.GraceDelay},
+	}
+	for _, d := range durations {
+		switch {
+		case changed(d.flag):
+			*d.dst = d.value
+		case d.cfg != "":
+			v, err := time.ParseDuration(d.cfg)
+			if err != nil || v < 0 {
+				return fmt.Errorf(i18n.T("error.dns_duration"), d.cfg)
+			}
+			*d.dst = v
+		}
+		if *d.dst < 0 {
+			return fmt.Errorf(i18n.T("error.dns_duration"), d.value)
+		}
+	}
+
+	if changed("dns-recursive-check") {
+		opts.Recursive = flagDNSRecursiveCheck
+	}
+
+	dns.SetCheckOptions(opts)
+	return nil
+}
+
+// printPropagationReport 以表格输出各服务器的传播检查结果
+func printPropagationReport(r *dns.Report) {
+	fmt.Println()
+	ui.Info(fmt.Sprintf(i18n.T("propagation.title"), strings.TrimSuffix(r.FQDN, ".")))
+	fmt.Println()
+
+	rows := make([][]string, len(r.Servers))
+	for i, s := range r.Servers {
+		rows[i] = []string{s.Server, s.Kind(), s.Status.Label(), s.LatencyText(), s.Detail()}
+	}
+	ui.Table([]string{
+		i18n.T("propagation.server"),
+		i18n.T("propagation.kind"),
+		i18n.T("propagation.status"),
+		i18n.T("propagation.latency"),
+		i18n.T("propagation.values"),
+	}, rows)
+	if unreachable := r.Unreachable(); len(unreachable) > 0 {
+		ui.Warning(fmt.Sprintf(i18n.T("propagation.unreachable"), strings.Join(unreachable, ", ")))
+	}
+}
+
+// printPropagationError 错误为传播检查超时时输出检查结果
+func printPropagationError(err error) {
+	var perr *dns.PropagationError
+	if errors.As(err, &perr) && perr.Report != nil {
+		printPropagationReport(perr.Report)
+	}
+}
+
+// failedPropagation 将未生效的检查结果转为文本，用于 AI 诊断
+func failedPropagation(reports []*dns.Report) string {
+	var parts []string
+	for _, r := range reports {
+		if !r.Propagated {
+			parts = append(parts, r.String())
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// setDNSFlags 将凭证写入对应参数，交互模式用于把表单结果传给 runApply
+func setDNSFlags(provider string, c dns.Credentials) {
+	for key, value := range dnsFlags[provider] {
+		*value = c[key]
+	}
+}
+
+// resetDNSFlags 清空所有提供商的凭证参数，交互模式下避免沿用上一次操作输入的凭证
+func resetDNSFlags() {
+	for provider := range dnsFlags {
+		setDNSFlags(provider, nil)
+	}
+}
+
+// savedDNSCredentials 读取已保存配置中的凭证，兼容只有 AccessKeyID/AccessKeySecret 的旧配置
+func savedDNSCredentials(p dns.Provider, cfg config.DNSConfig) dns.Credentials {
+	if len(cfg.Credentials) > 0 {
+		c := dns.Credentials{}
+		for k, v := range cfg.Credentials {
+			c[k] = v
+		}
+		return c
+	}
+	return p.LegacyCredentials(cfg.AccessKeyID, cfg.AccessKeySecret)
+}
+
+// loadDNSConfig 按名称加载已保存的配置并确认提供商
+func loadDNSConfig(name string) (dns.Provider, config.DNSConfig, error) {
+	cfg, ok := config.GetDNSConfigByName(name)
+	if !ok {
+		return dns.Provider{}, cfg, fmt.Errorf(i18n.T("error.dns_config_not_found"), name)
+	}
+	p, ok := dns.Get(cfg.Provider)
+	if !ok {
+		return dns.Provider{}, cfg, fmt.Errorf(i18n.T("error.dns_unsupported"), cfg.Provider, strings.Join(dns.Names(), ", "))
+	}
+	return p, cfg, nil
+}
+
+// mergeDNSCredentials 合并凭证，优先级：已保存配置 > 环境变量
+func mergeDNSCredentials(p dns.Provider, saved *config.DNSConfig) dns.Credentials {
+	c := p.FromEnv()
+	if saved != nil {
+		for k, v := range savedDNSCredentials(p, *saved) {
+			if v != "" {
+				c[k] = v
+			}
+		}
+	}
+	return c
+}
+
+// resolveDNSCredentials 合并凭证，优先级：命令行参数 > 已保存配置 > 环境变量
+func resolveDNSCredentials(p dns.Provider, saved *config.DNSConfig) dns.Credentials {
+	c := mergeDNSCredentials(p, saved)
+	for k, v := range dnsFlags[p.Name] {
+		if *v != "" {
+			c[k] = *v
+		}
+	}
+	return c
+}
+
+// sameDNSCredentials 比较两组凭证，忽略空值
+func sameDNSCredentials(a, b dns.Credentials) bool {
+	count := 0
+	for k, v := range a {
+		if v == "" {
+			continue
+		}
+		if b[k] != v {
+			return false
+		}
+		count++
+	}
+	for _, v := range b {
+		if v != "" {
+			count--
+		}
+	}
+	return count == 0
+}
+
+// printRenewDNSHints 提示续期时如何取得同样的凭证，凭证不会自动保存
+// 记录的 DNS 配置或环境变量能得到同样的凭证时只提示用到的环境变量，否则提示续期时需要的参数和环境变量
+func printRenewDNSHints(p dns.Provider, creds dns.Credentials, configName string) {
+	var saved *config.DNSConfig
+	if configName != "" {
+		if cfg, ok := config.GetDNSConfigByName(configName); ok {
+			saved = &cfg
+		}
+	}
+	if !sameDNSCredentials(mergeDNSCredentials(p, saved), creds) {
+		ui.Warning(i18n.T("hint.renew_dns_unsaved"))
+		for _, hint := range dnsCredentialHints(p) {
+			ui.Detail(hint)
+		}
+		return
+	}
+	if configName == "" {
+		if envs := dnsCredentialEnvs(p, creds); len(envs) > 0 {
+			ui.Info(fmt.Sprintf(i18n.T("hint.renew_dns_env"), strings.Join(envs, ", ")))
+		}
+	}
+}
+
+// dnsCredentialEnvs 返回提供凭证的环境变量
+func dnsCredentialEnvs(p dns.Provider, c dns.Credentials) []string {
+	var envs []string
+	for _, f := range p.Fields {
+		for _, env := range f.Env {
+			if v := os.Getenv(env); v != "" && v == c[f.Key] {
+				envs = append(envs, env)
+				break
+			}
+		}
+	}
+	return envs
+}
+
+// promptDNSCredentials 提示输入缺少的必填字段
+func promptDNSCredentials(p dns.Provider, c dns.Credentials) {
+	for _, f := range p.Missing(c) {
+		label := p.DisplayName() + " " + f.Label
+		if f.Secret {
+			c[f.Key] = ui.PromptSecret(label)
+		} else {
+			c[f.Key] = ui.Prompt(label)
+		}
+	}
+}
+
+// dnsCredentialHints 凭证缺失时的提示：参数、环境变量和获取地址
+func dnsCredentialHints(p dns.Provider) []string {
+	var flags, envs []string
+	for _, f := range p.Fields {
+		if f.Optional {
+			continue
+		}
+		flags = append(flags, "--"+f.Flag)
+		if len(f.Env) > 0 {
+			envs = append(envs, f.Env[0])
+		}
+	}
+
+	hints := []string{fmt.Sprintf(i18n.T("hint.dns_flags"), strings.Join(flags, ", "))}
+	if len(envs) > 0 {
+		hints = append(hints, fmt.Sprintf(i18n.T("hint.dns_env"), strings.Join(envs, ", ")))
+	}
+	if p.HelpURL != "" {
+		hints = append(hints, fmt.Sprintf(i18n.T("hint.dns_url"), p.HelpURL))
+	}
+	return hints
+}
+
+// inputDNSCredentials 交互表单，逐个输入提供商的凭证字段
+func inputDNSCredentials(p dns.Provider) (dns.Credentials, bool) {
+	env := p.FromEnv()
+	c := dns.Credentials{}
+	for _, f := range p.Fields {
+		label := p.DisplayName() + " " + f.Label
+		var value string
+		var err error
+		if f.Secret {
+			value, err = ui.InputSecret(label)
+		} else {
+			value, err = ui.Input(label, env[f.Key])
+		}
+		if err != nil {
+			return nil, false
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" && !f.Optional {
+			ui.Error(fmt.Sprintf(i18n.T("ui.field_empty"), f.Label))
+			return nil, false
+		}
+		if value != "" {
+			c[f.Key] = value
+		}
+	}
+	return c, true
+}
+
+// selectDNSCredentials 选择已保存的配置或输入新凭证，新凭证可保存到本地
+// 返回使用或新保存的配置名称，未保存时为空
+func selectDNSCredentials(p dns.Provider) (dns.Credentials, string, bool) {
+	saved := config.GetDNSConfigsByProvider(p.Name)
+	if len(saved) > 0 {
+		options := []string{}
+		for _, cfg := range saved {
+			options = append(options, fmt.Sprintf("%s (%s)", cfg.Name, p.Mask(savedDNSCredentials(p, cfg))))
+		}
+		options = append(options, i18n.T("ui.input_new_config"))
+
+		idx, _, err := ui.Select(fmt.Sprintf(i18n.T("ui.select_dns_cfg"), p.DisplayName()), options)
+		if err != nil {
+			return nil, "", false
+		}
+		if idx < len(saved) {
+			ui.Success(fmt.Sprintf(i18n.T("ui.using_config"), saved[idx].Name))
+			return savedDNSCredentials(p, saved[idx]), saved[idx].Name, true
+		}
+	}
+
+	creds, ok := inputDNSCredentials(p)
+	if !ok {
+		return nil, "", false
+	}
+
+	configName := ""
+	if ui.ConfirmPrompt(i18n.T("ui.save_config_local")) {
+		label := i18n.T("ui.config_name")
+		if len(saved) > 0 {
+			label = i18n.T("ui.config_name_new")
+		}
+		if name, _ := ui.Input(label, ""); name != "" {
+			config.AddDNSConfig(name, p.Name, creds)
+			configName = name
+			ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
+		}
+	}
+	return creds, configName, true
+}
+
+// addDNSConfig 在 DNS 配置管理中添加配置
+func addDNSConfig(p dns.Provider) {
+	name, err := ui.Input(i18n.T("ui.config_name"), "")
+	if err != nil || name == "" {
+		ui.Error(i18n.T("ui.name_empty"))
+		return
+	}
+
+	creds, ok := inputDNSCredentials(p)
+	if !ok {
+		return
+	}
+
+	config.AddDNSConfig(name, p.Name, creds)
+	ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
+}
+
+// dnsConfigSummary 配置列表中显示的提供商和凭证摘要
+func dnsConfigSummary(cfg config.DNSConfig) string {
+	p, ok := dns.Get(cfg.Provider)
+	if !ok {
+		return cfg.Provider
+	}
+	return fmt.Sprintf("%s (%s)", p.DisplayName(), p.Mask(savedDNSCredentials(p, cfg)))
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"certctl/internal/acme"
+	"certctl/internal/cert"
+	"certctl/internal/config"
+	"certctl/internal/dns"
+	"certctl/internal/hook"
+	"certctl/internal/i18n"
+	"certctl/internal/ui"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// issuedCert 已保存的证书
+type issuedCert struct {
+	*acme.Certificate
+	CertPath string // 绝对路径
+	KeyPath  string
+}
+
+// certKeyTypes 返回本次需要签发的私钥类型，双证书模式下先 ECDSA 后 RSA
+func certKeyTypes(keyType acme.KeyType, dual bool) []acme.KeyType {
+	if !dual {
+		return []acme.KeyType{keyType}
+	}
+	ec, rsa := acme.DualKeyTypes(keyType)
+	return []acme.KeyType{ec, rsa}
+}
+
+// obtainCertificates 依次签发各私钥类型的证书
+// 同一账户下已通过的 DNS-01 授权会被 CA 复用，第二张证书无需再次验证
+// 某个私钥类型失败时同时返回已签发的证书，调用方应保存它们，避免重复签发消耗 CA 的配额
+func obtainCertificates(client *acme.Client, domains []string, keyTypes []acme.KeyType) ([]*acme.Certificate, error) {
+	var certs []*acme.Certificate
+	for _, kt := range keyTypes {
+		certificate, err := client.ObtainCertificate(domains, kt)
+		if err != nil {
+			return certs, err
+		}
+		certs = append(certs, certificate)
+	}
+	return certs, nil
+}
+
+// partialIssueError 双证书模式下后一张证书签发失败，已签发的证书已保存
+type partialIssueError struct {
+	Saved  []issuedCert
+	Failed acme.KeyType
+	Err    error
+}
+
+func (e *partialIssueError) Error() string {
+	var paths []string
+	for _, c := range e.Saved {
+		paths = append(paths, c.CertPath)
+	}
+	return fmt.Sprintf(i18n.T("error.partial_issue"), e.Failed, e.Err, strings.Join(paths, ", "))
+}
+
+func (e *partialIssueError) Unwrap() error {
+	return e.Err
+}
+
+// savePartial 签发中途失败时保存已签发的证书，没有已签发的证书或保存失败时返回 nil
+func savePartial(outputDir, name string, certs []*acme.Certificate, keyTypes []acme.KeyType, dual bool, err error) *partialIssueError {
+	if len(certs) == 0 {
+		return nil
+	}
+	saved, saveErr := saveCertificates(outputDir, name, certs, dual)
+	if saveErr != nil {
+		ui.Warning(fmt.Sprintf(i18n.T("error.partial_save"), saveErr))
+		return nil
+	}
+	return &partialIssueError{Saved: saved, Failed: keyTypes[len(saved)], Err: err}
+}
+
+// printPartialIssue 提示已保存的证书，失败原因由调用方随后显示
+func printPartialIssue(partial *partialIssueError) {
+	ui.Warning(fmt.Sprintf(i18n.T("warn.partial_issue"), partial.Failed))
+	for _, c := range partial.Saved {
+		ui.CertResult(c.CertPath, c.KeyPath, c.NotAfter.Format("2006-01-02"))
+	}
+}
+
+// saveCertificates 保存证书，双证书模式下按 ecc/rsa 变体并存
+func saveCertificates(outputDir, name string, certs []*acme.Certificate, dual bool) ([]issuedCert, error) {
+	absOut, _ := filepath.Abs(outputDir)
+
+	var saved []issuedCert
+	for _, c := range certs {
+		variant := ""
+		if dual {
+			variant = certVariant(c.KeyType)
+		}
+		if _, _, err := cert.SaveVariant(outputDir, name, variant, c.Certificate, c.PrivateKey); err != nil {
+			return saved, err
+		}
+		certPath, keyPath := cert.Paths(absOut, name, variant)
+		saved = append(saved, issuedCert{Certificate: c, CertPath: certPath, KeyPath: keyPath})
+	}
+	return saved, nil
+}
+
+// certVariant 私钥类型对应的文件变体
+func certVariant(kt acme.KeyType) string {
+	if kt.IsRSA() {
+		return cert.VariantRSA
+	}
+	return cert.VariantECC
+}
+
+// runHooks 依次执行全局钩子和证书钩子
+func runHooks(stage hook.Stage, certHooks hook.Hooks, env hook.Env) []error {
+	return hook.RunAll(stage, env, config.Get().Hooks, certHooks)
+}
+
+// runDeployHooks 每个新保存的证书文件执行一次 deploy 钩子，失败不影响已保存的证书
+func runDeployHooks(name string, domains []string, certHooks hook.Hooks, saved []issuedCert) []error {
+	var errs []error
+	for _, c := range saved {
+		errs = append(errs, runHooks(hook.StageDeploy, certHooks, hook.Env{
+			Domain:   name,
+			Domains:  domains,
+			CertPath: c.CertPath,
+			KeyPath:  c.KeyPath,
+			NotAfter: c.NotAfter,
+		})...)
+	}
+	return errs
+}
+
+// hookHints 将钩子错误转为提示列表
+func hookHints(errs []error) []string {
+	hints := make([]string, len(errs))
+	for i, err := range errs {
+		hints[i] = err.Error()
+	}
+	return hints
+}
+
+// errorHints 将错误转为提示列表，多行错误（如 DNS 脚本的 stderr）逐行显示
+func errorHints(err error) []string {
+	var hints []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		if len(hints) == 0 {
+			line = "Error: " + line
+		}
+		hints = append(hints, line)
+	}
+	return hints
+}
+
+// interruptContext 返回收到 SIGINT/SIGTERM 时取消的 context，调用 stop 停止捕获信号
+// 第一次信号只取消 context，进行中的请求随之中止，已添加的验证记录会被清理；
+// 之后恢复默认处理，再次按 Ctrl-C 立即退出
+func interruptContext(parent context.Context) (ctx context.Context, stop context.CancelFunc) {
+	ctx, stop = signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// printInterrupted 显示取消后的清理结果，manual 为手动验证，记录需要用户自己删除
+func printInterrupted(err *acme.InterruptedError, manual bool) {
+	ui.Warning(i18n.T("interrupt.cancelled"))
+	if manual {
+		ui.Info(i18n.T("interrupt.manual"))
+		return
+	}
+	if len(err.Cleaned) > 0 {
+		ui.Info(fmt.Sprintf(i18n.T("interrupt.cleaned"), strings.Join(err.Cleaned, ", ")))
+	}
+	if len(err.Failed) > 0 {
+		ui.ErrorWithHint(i18n.T("interrupt.cleanup_fail"), hookHints(err.Failed))
+	}
+}
+
+// autoDNSCredentials 读取续期使用的 DNS 凭证，优先级：命令行参数 > 记录的 DNS 配置 > 环境变量
+func autoDNSCredentials(provider, configName string) (dns.Provider, dns.Credentials, error) {
+	p, ok := dns.Get(provider)
+	if !ok {
+		return dns.Provider{}, nil, fmt.Errorf(i18n.T("error.dns_unsupported"), provider, strings.Join(dns.Names(), ", "))
+	}
+
+	var saved *config.DNSConfig
+	if configName != "" {
+		cfgProvider, cfg, err := loadDNSConfig(configName)
+		if err != nil {
+			return dns.Provider{}, nil, err
+		}
+		if cfgProvider.Name != p.Name {
+			return dns.Provider{}, nil, fmt.Errorf("DNS 配置「%s」属于 %s，而不是 %s", configName, cfgProvider.Name, p.Name)
+		}
+		saved = &cfg
+	}
+	return p, resolveDNSCredentials(p, saved), nil
+}
+
+// newAutoDNSProvider 创建自动 DNS 验证提供者，用于无人值守续期
+// 凭证来自命令行参数、记录的 DNS 配置和环境变量，不会交互提示
+func newAutoDNSProvider(provider, configName string) (challenge.Provider, error) {
+	p, creds, err := autoDNSCredentials(provider, configName)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(creds); err != nil {
+		return nil, fmt.Errorf("%v（%s）", err, strings.Join(dnsCredentialHints(p), "；"))
+	}
+	return p.New(creds)
+}
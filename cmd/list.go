@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"certctl/internal/cert"
@@ -61,7 +62,11 @@ func runList(cmd *cobra.Command, args []string) error {
 			status = fmt.Sprintf("✔ %d 天后过期", daysLeft)
 		}
 
-		fmt.Printf("  %s\n", c.Domain)
+		if c.Variant != "" {
+			fmt.Printf("  %s (%s)\n", c.Domain, strings.ToUpper(c.Variant))
+		} else {
+			fmt.Printf("  %s\n", c.Domain)
+		}
 		fmt.Printf("    状态: %s\n", status)
 		fmt.Printf("    有效期至: %s\n", c.NotAfter.Format("2006-01-02"))
 		fmt.Printf("    证书: %s\n", c.CertPath)
@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	"certctl/internal/acme"
 	"certctl/internal/cert"
+	"certctl/internal/config"
 	"certctl/internal/dns"
+	"certctl/internal/hook"
+	"certctl/internal/i18n"
 	"certctl/internal/ui"
 	"certctl/pkg/domain"
 
@@ -21,30 +25,58 @@ var (
 	renewEmail   string
 	renewOutput  string
 	renewStaging bool
+	renewCA      string
+	renewCAURL   string
+	renewEABKID  string
+	renewEABHMAC string
+	renewKeyType string
+	renewAll     bool
+	renewDays    int
 )
 
 var renewCmd = &cobra.Command{
 	Use:   "renew",
 	Short: "续期 SSL 证书",
-	Long:  "续期已申请的 SSL 证书，需要重新进行 DNS 验证",
+	Long:  "续期已申请的 SSL 证书。使用自动 DNS 验证申请的证书会按记录的参数无人值守续期，手动验证的证书需要重新添加 DNS 记录",
 	RunE:  runRenew,
 }
 
 func init() {
 	rootCmd.AddCommand(renewCmd)
 
-	renewCmd.Flags().StringVarP(&renewDomain, "domain", "d", "", "要续期的域名")
+	renewCmd.Flags().StringVarP(&renewDomain, "domain", "d", "", "要续期的证书名称（证书目录名，通常为主域名）")
 	renewCmd.Flags().StringVarP(&renewEmail, "email", "e", "", "Let's Encrypt 账户邮箱（可选，使用已保存的账户）")
-	renewCmd.Flags().StringVarP(&renewOutput, "output", "o", "./certs", "证书输出目录")
-	renewCmd.Flags().BoolVar(&renewStaging, "staging", false, "使用 Let's Encrypt 测试环境")
+	renewCmd.Flags().StringVarP(&renewOutput, "output", "o", "", "证书输出目录（默认使用配置中的证书目录）")
+	renewCmd.Flags().BoolVar(&renewStaging, "staging", false, "使用测试环境（默认 Let's Encrypt，配合 --ca 时为该 CA 的测试环境）")
+	renewCmd.Flags().StringVar(&renewCA, "ca", "", "CA 名称（默认沿用申请时的 CA）")
+	renewCmd.Flags().StringVar(&renewCAURL, "ca-url", "", "自定义 ACME 目录地址（默认沿用申请时的 CA）")
+	renewCmd.Flags().StringVar(&renewEABKID, "eab-kid", "", "External Account Binding Key ID（仅首次注册账户时需要）")
+	renewCmd.Flags().StringVar(&renewEABHMAC, "eab-hmac", "", "External Account Binding HMAC Key")
+	renewCmd.Flags().StringVar(&renewKeyType, "key-type", "", "证书私钥类型（默认沿用申请时的类型）")
+	renewCmd.Flags().BoolVar(&renewAll, "all", false, "批量续期证书目录下所有即将到期的证书")
+	renewCmd.Flags().IntVar(&renewDays, "days", 30, "配合 --all 使用，剩余天数少于该值的证书才会续期")
+	addDNSFlags(renewCmd)
+	addDNSCheckFlags(renewCmd)
 }
 
 func runRenew(cmd *cobra.Command, args []string) error {
 	// 禁用 lego 库的日志输出
 	legolog.Logger = &noopLogger{}
+	if err := applyDNSCheckFlags(cmd); err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
 
 	fmt.Println()
 
+	if renewOutput == "" {
+		renewOutput = config.Get().CertsDir
+	}
+
+	if renewAll {
+		return runRenewAll(cmd)
+	}
+
 	// 1. 获取域名
 	inputDomain := renewDomain
 	if inputDomain == "" {
@@ -55,19 +87,79 @@ func runRenew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// 2. 生成通配符域名
-	domains, err := domain.GenerateWildcard(inputDomain)
+	// 读取申请时记录的参数，域名按申请时的规则转换为证书名，自定义名称原样使用
+	certName := inputDomain
+	if d, err := domain.Normalize(inputDomain); err == nil {
+		certName = domain.CertName([]string{d})
+	}
+	meta, err := cert.LoadMeta(renewOutput, certName)
 	if err != nil {
-		ui.Error(fmt.Sprintf("域名解析失败: %v", err))
+		ui.Warning(fmt.Sprintf("读取证书元数据失败: %v", err))
+	}
+	dual := meta != nil && meta.Dual
+
+	// 申请时使用了自动 DNS 验证，按记录的参数无人值守续期
+	if meta != nil && meta.DNS != "" {
+		// 无人值守续期不交互询问，凭证缺失时直接报错并提示续期需要的参数和环境变量
+		p, creds, err := autoDNSCredentials(meta.DNS, meta.DNSConfig)
+		if err != nil {
+			ui.Error(err.Error())
+			return nil
+		}
+		if err := p.Validate(creds); err != nil {
+			ui.ErrorWithHint(err.Error(), dnsCredentialHints(p))
+			return nil
+		}
+
+		ctx, stop := interruptContext(context.Background())
+		defer stop()
+
+		spin := ui.NewSpinner(fmt.Sprintf("正在续期 %s ...", certName))
+		spin.Start()
+		saved, hookErrs, err := renewUnattended(ctx, renewOutput, certName, meta)
+		spin.Stop()
+		for _, hookErr := range hookErrs {
+			ui.Warning(fmt.Sprintf("钩子执行失败: %v", hookErr))
+		}
+		var interrupted *acme.InterruptedError
+		if errors.As(err, &interrupted) {
+			printInterrupted(interrupted, false)
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			ui.ErrorWithHint("证书续期失败", errorHints(err))
+			return nil
+		}
+		ui.Success("证书续期成功!")
+		for _, c := range saved {
+			ui.CertResult(c.CertPath, c.KeyPath, c.NotAfter.Format("2006-01-02"))
+		}
+		fmt.Println()
 		return nil
 	}
 
-	rootDomain := domains[0]
+	// 2. 确定证书域名：优先使用记录的域名列表，旧证书按 根域名 + 通配符 处理
+	var domains []string
+	if meta != nil && len(meta.Domains) > 0 {
+		domains = meta.Domains
+	} else {
+		domains, err = domain.GenerateWildcard(inputDomain)
+		if err != nil {
+			ui.Error(fmt.Sprintf("域名解析失败: %v", err))
+			return nil
+		}
+		certName = domains[0]
+	}
 
 	// 3. 检查证书是否存在
-	certPath := filepath.Join(renewOutput, rootDomain, rootDomain+".pem")
+	variant := ""
+	if dual {
+		variant = cert.VariantECC
+	}
+	certPath, _ := cert.Paths(renewOutput, certName, variant)
 	if _, err := os.Stat(certPath); os.IsNotExist(err) {
-		ui.Warning(fmt.Sprintf("未找到域名 %s 的证书，将进行首次申请", rootDomain))
+		ui.Warning(fmt.Sprintf("未找到证书 %s，将进行首次申请", certName))
 	} else {
 		// 显示当前证书信息
 		notAfter, err := cert.ParseCertExpiry(certPath)
@@ -85,11 +177,24 @@ func runRenew(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// 沿用申请时记录的 CA 和私钥类型，除非通过参数显式指定
+	ca, keyType, err := renewSettings(meta)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
 	// 4. 确认续期
 	ui.Title("将为以下域名续期证书:")
 	fmt.Println()
 	ui.DomainList(domains)
 	fmt.Println()
+	ui.Detail(fmt.Sprintf("CA: %s", ca))
+	keyTypes := certKeyTypes(keyType, dual)
+	for _, kt := range keyTypes {
+		ui.Detail(fmt.Sprintf("私钥类型: %s", kt))
+	}
+	fmt.Println()
 
 	if !ui.Confirm("继续?") {
 		ui.Info("已取消")
@@ -104,44 +209,38 @@ func runRenew(cmd *cobra.Command, args []string) error {
 	spin := ui.NewSpinner("正在加载账户信息...")
 	spin.Start()
 
-	// 尝试加载已有账户
-	var account *acme.Account
-	email := renewEmail
-
-	// 如果没有指定邮箱，尝试从配置中加载
+	// 按 CA 和邮箱选择账户
+	email := selectAccount(configDir, ca, renewEmail, metaEmail(meta))
 	if email == "" {
-		existingAccount, err := acme.LoadOrCreateAccount(configDir, "")
-		if err == nil && existingAccount.Email != "" {
-			account = existingAccount
-			email = existingAccount.Email
-		}
-	}
-
-	if account == nil {
 		spin.Stop()
+		email = ui.Prompt("请输入邮箱 (用于 ACME 账户):")
 		if email == "" {
-			email = ui.Prompt("请输入邮箱 (用于 Let's Encrypt 账户):")
-			if email == "" {
-				ui.Error("邮箱不能为空")
-				return nil
-			}
+			ui.Error("邮箱不能为空")
+			return nil
 		}
 		spin = ui.NewSpinner("正在初始化 ACME 客户端...")
 		spin.Start()
+	}
 
-		account, err = acme.LoadOrCreateAccount(configDir, email)
-		if err != nil {
-			spin.Stop()
-			ui.Error(fmt.Sprintf("加载账户失败: %v", err))
-			return nil
-		}
+	account, err := acme.LoadOrCreateAccount(configDir, ca.DirectoryURL, email)
+	if err != nil {
+		spin.Stop()
+		ui.Error(fmt.Sprintf("加载账户失败: %v", err))
+		return nil
 	}
 
+	// 取消后 ACME 客户端会中止请求，手动验证只在检查 DNS 记录时捕获 Ctrl-C
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	// 创建 DNS Provider，onPresent 回调会阻塞直到 DNS 验证通过
 	provider := acme.NewManualDNSProvider(
-		func(c *acme.Challenge) error {
+		func(ctx context.Context, c *acme.Challenge) error {
 			// 显示 DNS 记录信息
 			fmt.Println()
+			if c.Alias != "" {
+				ui.Info(fmt.Sprintf("验证记录已通过 CNAME 委托: %s → %s", c.Alias, c.FQDN))
+			}
 			ui.DNSRecord(
 				c.RecordName,
 				"TXT",
@@ -163,13 +262,21 @@ func runRenew(cmd *cobra.Command, args []string) error {
 			checkSpin := ui.NewSpinner("检查 DNS 记录是否生效...")
 			checkSpin.Start()
 
-			err := dns.WaitForRecord(c.FQDN, c.Value, 5*time.Minute, func(attempt int) {
+			waitCtx, stop := interruptContext(ctx)
+			err := dns.WaitForRecord(waitCtx, c.FQDN, c.Value, func(attempt int) {
 				checkSpin.Suffix = fmt.Sprintf(" 检查 DNS 记录... (第 %d 次)", attempt)
 			})
+			interrupted := waitCtx.Err() != nil && ctx.Err() == nil
+			stop()
 
 			checkSpin.Stop()
 
+			if interrupted {
+				cancel()
+				return context.Canceled
+			}
 			if err != nil {
+				printPropagationError(err)
 				ui.Error("DNS 记录验证超时，请确认记录已正确添加")
 				return err
 			}
@@ -182,7 +289,7 @@ func runRenew(cmd *cobra.Command, args []string) error {
 		nil,
 	)
 
-	client, err := acme.NewClient(account, renewStaging, provider)
+	client, err := acme.NewClient(ctx, account, ca, provider)
 	if err != nil {
 		spin.Stop()
 		ui.Error(fmt.Sprintf("创建客户端失败: %v", err))
@@ -207,11 +314,56 @@ func runRenew(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// 6. 申请证书（onPresent 会阻塞等待 DNS 验证通过）
-	ui.Info("正在与 Let's Encrypt 通信...")
+	ui.Info(fmt.Sprintf("正在与 %s 通信...", ca.Name))
 	fmt.Println()
 
-	certificate, err := client.ObtainCertificate(domains)
+	var certHooks hook.Hooks
+	if meta != nil {
+		certHooks = meta.Hooks
+	}
+	hookEnv := hook.Env{Domain: certName, Domains: domains}
+	if errs := runHooks(hook.StagePre, certHooks, hookEnv); len(errs) > 0 {
+		ui.ErrorWithHint("pre-hook 执行失败，已取消续期", hookHints(errs))
+		return nil
+	}
+
+	certificates, err := obtainCertificates(client, domains, keyTypes)
 
+	for _, hookErr := range runHooks(hook.StagePost, certHooks, hookEnv) {
+		ui.Warning(fmt.Sprintf("钩子执行失败: %v", hookErr))
+	}
+
+	saveMeta := func() {
+		newMeta := &cert.Meta{}
+		if meta != nil {
+			*newMeta = *meta
+		}
+		newMeta.Name = certName
+		newMeta.Domains = domains
+		newMeta.CA = ca.Name
+		newMeta.CAURL = ca.DirectoryURL
+		newMeta.Email = email
+		newMeta.KeyType = string(keyType)
+		newMeta.Dual = dual
+		if err := cert.SaveMeta(renewOutput, certName, newMeta); err != nil {
+			ui.Warning(fmt.Sprintf("保存证书元数据失败: %v", err))
+		}
+	}
+
+	// 双证书模式下后一张证书失败时，已签发的证书照常保存，不运行 deploy 钩子
+	if err != nil {
+		if partial := savePartial(renewOutput, certName, certificates, keyTypes, dual, err); partial != nil {
+			saveMeta()
+			printPartialIssue(partial)
+		}
+	}
+
+	var interrupted *acme.InterruptedError
+	if errors.As(err, &interrupted) {
+		printInterrupted(interrupted, true)
+		fmt.Println()
+		return nil
+	}
 	if err != nil {
 		ui.Error(fmt.Sprintf("证书续期失败: %v", err))
 		return nil
@@ -221,20 +373,293 @@ func runRenew(cmd *cobra.Command, args []string) error {
 	fmt.Println()
 
 	// 7. 保存证书
-	certPathNew, keyPath, err := cert.Save(renewOutput, rootDomain, certificate.Certificate, certificate.PrivateKey)
+	saved, err := saveCertificates(renewOutput, certName, certificates, dual)
 	if err != nil {
 		ui.Error(fmt.Sprintf("保存证书失败: %v", err))
 		return nil
 	}
 
-	// 转换为绝对路径
-	absOut, _ := filepath.Abs(renewOutput)
-	certPathNew = filepath.Join(absOut, rootDomain, rootDomain+".pem")
-	keyPath = filepath.Join(absOut, rootDomain, rootDomain+".key")
+	saveMeta()
+
+	for _, hookErr := range runDeployHooks(certName, domains, certHooks, saved) {
+		ui.Warning(fmt.Sprintf("钩子执行失败: %v", hookErr))
+	}
 
 	// 8. 显示结果
-	ui.CertResult(certPathNew, keyPath, certificate.NotAfter.Format("2006-01-02"))
+	for _, c := range saved {
+		ui.CertResult(c.CertPath, c.KeyPath, c.NotAfter.Format("2006-01-02"))
+	}
 	fmt.Println()
 
 	return nil
 }
+
+func metaEmail(meta *cert.Meta) string {
+	if meta == nil {
+		return ""
+	}
+	return meta.Email
+}
+
+// renewSettings 计算续期使用的 CA 和私钥类型：命令行参数优先，其次是证书记录的参数
+func renewSettings(meta *cert.Meta) (acme.CA, acme.KeyType, error) {
+	caName, caURL := renewCA, renewCAURL
+	if caName == "" && caURL == "" && !renewStaging && meta != nil {
+		caName, caURL = meta.CA, meta.CAURL
+	}
+	ca, err := resolveCA(caName, caURL, renewStaging)
+	if err == nil {
+		ca, err = withEAB(ca, renewEABKID, renewEABHMAC)
+	}
+	if err != nil {
+		return acme.CA{}, "", fmt.Errorf("CA 配置无效: %v", err)
+	}
+
+	keyTypeName := renewKeyType
+	if keyTypeName == "" && meta != nil {
+		keyTypeName = meta.KeyType
+	}
+	keyType, err := acme.ParseKeyType(keyTypeName)
+	if err != nil {
+		return acme.CA{}, "", err
+	}
+	return ca, keyType, nil
+}
+
+// renewUnattended 按证书元数据无人值守续期，全程不会交互提示
+// 钩子失败单独返回，不视为续期失败（pre-hook 除外，它会取消续期）
+// ctx 取消时中止续期、清理已添加的验证记录并返回 *acme.InterruptedError
+func renewUnattended(ctx context.Context, outputDir, name string, meta *cert.Meta) (saved []issuedCert, hookErrs []error, err error) {
+	ca, keyType, err := renewSettings(meta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	domains := meta.Domains
+	if len(domains) == 0 {
+		if domains, err = domain.GenerateWildcard(name); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 账户：参数指定 > 证书记录 > 该 CA 下唯一账户
+	configDir := getConfigDir()
+	email := selectAccount(configDir, ca, renewEmail, meta.Email)
+	if email == "" {
+		return nil, nil, fmt.Errorf("无法确定 %s 使用的 ACME 账户，请通过 -e 指定邮箱", ca.Name)
+	}
+	account, err := acme.LoadOrCreateAccount(configDir, ca.DirectoryURL, email)
+	if err != nil {
+		return nil, nil, fmt.Errorf("加载账户失败: %v", err)
+	}
+
+	provider, err := newAutoDNSProvider(meta.DNS, meta.DNSConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := acme.NewClient(ctx, account, ca, provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Register(); err != nil {
+		return nil, nil, err
+	}
+	if err := acme.SaveAccount(configDir, account); err != nil {
+		return nil, nil, fmt.Errorf("保存账户失败: %v", err)
+	}
+
+	hookEnv := hook.Env{Domain: name, Domains: domains}
+	if errs := runHooks(hook.StagePre, meta.Hooks, hookEnv); len(errs) > 0 {
+		return nil, errs, fmt.Errorf("pre-hook 执行失败，已取消续期")
+	}
+
+	keyTypes := certKeyTypes(keyType, meta.Dual)
+	certificates, err := obtainCertificates(client, domains, keyTypes)
+	hookErrs = runHooks(hook.StagePost, meta.Hooks, hookEnv)
+
+	newMeta := *meta
+	newMeta.Name = name
+	newMeta.Domains = domains
+	newMeta.CA = ca.Name
+	newMeta.CAURL = ca.DirectoryURL
+	newMeta.Email = email
+	newMeta.KeyType = string(keyType)
+
+	// 双证书模式下后一张证书失败时，已签发的证书照常保存，不运行 deploy 钩子
+	if err != nil {
+		if partial := savePartial(outputDir, name, certificates, keyTypes, meta.Dual, err); partial != nil {
+			cert.SaveMeta(outputDir, name, &newMeta)
+			return partial.Saved, hookErrs, partial
+		}
+		return nil, hookErrs, err
+	}
+
+	saved, err = saveCertificates(outputDir, name, certificates, meta.Dual)
+	if err != nil {
+		return nil, hookErrs, fmt.Errorf("保存证书失败: %v", err)
+	}
+
+	if err := cert.SaveMeta(outputDir, name, &newMeta); err != nil {
+		return saved, hookErrs, fmt.Errorf("保存证书元数据失败: %v", err)
+	}
+	hookErrs = append(hookErrs, runDeployHooks(name, domains, meta.Hooks, saved)...)
+	return saved, hookErrs, nil
+}
+
+// 批量续期结果
+const (
+	renewStatusRenewed   = "renewed"
+	renewStatusSkipped   = "skipped"
+	renewStatusFailed    = "failed"
+	renewStatusCancelled = "cancelled" // 被中断前未轮到续期
+)
+
+// renewResult 单个证书的批量续期结果
+type renewResult struct {
+	Name     string
+	DaysLeft int
+	Status   string
+	NotAfter time.Time // 续期成功后的新到期时间
+	Err      error
+	HookErrs []error // 钩子失败不影响续期结果，单独报告
+}
+
+// renewDue 续期目录下剩余天数少于 days 的所有证书，单个证书失败不影响其他证书
+// onStart 在每个证书开始续期前调用，可为 nil；ctx 取消后不再续期剩余的证书，
+// 其中需要续期的证书以 renewStatusCancelled 列在结果中
+func renewDue(ctx context.Context, outputDir string, days int, onStart func(name string)) ([]renewResult, error) {
+	certs, err := cert.ListCertificates(outputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// 双证书模式下同一证书有多个文件，按证书名合并，取最早到期的一个
+	var names []string
+	daysLeft := map[string]int{}
+	for _, c := range certs {
+		left, ok := daysLeft[c.Domain]
+		if !ok {
+			names = append(names, c.Domain)
+		}
+		if !ok || c.DaysLeft < left {
+			daysLeft[c.Domain] = c.DaysLeft
+		}
+	}
+
+	results := make([]renewResult, 0, len(names))
+	for _, name := range names {
+		r := renewResult{Name: name, DaysLeft: daysLeft[name]}
+		if r.DaysLeft >= days {
+			r.Status = renewStatusSkipped
+			results = append(results, r)
+			continue
+		}
+		if ctx.Err() != nil {
+			r.Status = renewStatusCancelled
+			results = append(results, r)
+			continue
+		}
+
+		if onStart != nil {
+			onStart(name)
+		}
+		r.Status = renewStatusFailed
+		meta, err := cert.LoadMeta(outputDir, name)
+		switch {
+		case err != nil:
+			r.Err = fmt.Errorf("读取证书元数据失败: %v", err)
+		case meta == nil || meta.DNS == "":
+			r.Err = fmt.Errorf("该证书使用手动 DNS 验证，请执行 certctl renew -d %s", name)
+		default:
+			var saved []issuedCert
+			if saved, r.HookErrs, r.Err = renewUnattended(ctx, outputDir, name, meta); r.Err == nil {
+				r.Status = renewStatusRenewed
+				r.NotAfter = saved[0].NotAfter
+			}
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// runRenewAll 批量续期，有证书续期失败或因中断未续期时返回错误以便以非零状态码退出
+func runRenewAll(cmd *cobra.Command) error {
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	ui.Title(fmt.Sprintf("批量续期剩余不足 %d 天的证书: %s", renewDays, renewOutput))
+	fmt.Println()
+
+	ctx, stop := interruptContext(cmd.Context())
+	defer stop()
+
+	results, err := renewDue(ctx, renewOutput, renewDays, func(name string) {
+		ui.Info(fmt.Sprintf("正在续期 %s ...", name))
+	})
+	if err != nil {
+		return fmt.Errorf("扫描证书失败: %v", err)
+	}
+	if len(results) == 0 {
+		ui.Info("暂无已申请的证书")
+		return nil
+	}
+
+	var renewed, skipped, failed, cancelled int
+	var hookErrs []error
+	var interrupted *acme.InterruptedError
+	rows := make([][]string, 0, len(results))
+	for _, r := range results {
+		hookErrs = append(hookErrs, r.HookErrs...)
+		errors.As(r.Err, &interrupted)
+		detail := ""
+		status := ""
+		switch r.Status {
+		case renewStatusRenewed:
+			renewed++
+			status = "✔ 已续期"
+			detail = "有效期至 " + r.NotAfter.Format("2006-01-02")
+		case renewStatusSkipped:
+			skipped++
+			status = "- 无需续期"
+		case renewStatusFailed:
+			failed++
+			status = "✖ 失败"
+			detail = r.Err.Error()
+		case renewStatusCancelled:
+			cancelled++
+			status = "✖ 已取消"
+			detail = "续期被中断，未处理"
+		}
+		rows = append(rows, []string{r.Name, fmt.Sprintf("%d", r.DaysLeft), status, detail})
+	}
+
+	fmt.Println()
+	ui.Table([]string{"证书", "剩余天数", "结果", "说明"}, rows)
+	fmt.Println()
+	for _, hookErr := range hookErrs {
+		ui.Warning(fmt.Sprintf("钩子执行失败: %v", hookErr))
+	}
+	if interrupted != nil {
+		printInterrupted(interrupted, false)
+	} else if ctx.Err() != nil {
+		ui.Warning(i18n.T("interrupt.cancelled"))
+	}
+	summary := fmt.Sprintf("共 %d 个证书: 续期 %d, 跳过 %d, 失败 %d, 取消 %d", len(results), renewed, skipped, failed, cancelled)
+	if failed > 0 || cancelled > 0 {
+		ui.Error(summary)
+		fmt.Println()
+		if cancelled > 0 {
+			return fmt.Errorf("%d 个证书续期失败, %d 个证书因中断未续期", failed, cancelled)
+		}
+		return fmt.Errorf("%d 个证书续期失败", failed)
+	}
+	if len(hookErrs) > 0 {
+		ui.Warning(summary)
+		fmt.Println()
+		return fmt.Errorf("%d 个钩子执行失败", len(hookErrs))
+	}
+	ui.Success(summary)
+	fmt.Println()
+	return nil
+}
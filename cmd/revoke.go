@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"certctl/internal/acme"
+	"certctl/internal/cert"
+	"certctl/internal/config"
+	"certctl/internal/i18n"
+	"certctl/internal/ui"
+
+	legolog "github.com/go-acme/lego/v4/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	revokeDomain     string
+	revokeCertPath   string
+	revokeKeyPath    string
+	revokeOutput     string
+	revokeReason     string
+	revokeUseCertKey bool
+	revokeEmail      string
+	revokeCA         string
+	revokeCAURL      string
+	revokeStaging    bool
+	revokeYes        bool
+)
+
+var revokeCmd = &cobra.Command{
+	Use:   "revoke",
+	Short: "吊销 SSL 证书",
+	Long:  "吊销已签发的证书，成功后将证书文件移入 .archive 归档目录",
+	RunE:  runRevoke,
+}
+
+func init() {
+	rootCmd.AddCommand(revokeCmd)
+
+	revokeCmd.Flags().StringVarP(&revokeDomain, "domain", "d", "", "要吊销的证书名称（证书目录名）")
+	revokeCmd.Flags().StringVar(&revokeCertPath, "cert", "", "要吊销的证书 PEM 文件路径")
+	revokeCmd.Flags().StringVar(&revokeKeyPath, "key", "", "证书私钥路径（配合 --use-cert-key，默认按证书目录的命名规则查找）")
+	revokeCmd.Flags().StringVarP(&revokeOutput, "output", "o", "", "证书目录")
+	revokeCmd.Flags().StringVar(&revokeReason, "reason", "", "吊销原因 ("+strings.Join(acme.RevocationReasonNames(), "/")+")")
+	revokeCmd.Flags().BoolVar(&revokeUseCertKey, "use-cert-key", false, "使用证书私钥而不是账户私钥进行认证")
+	revokeCmd.Flags().StringVarP(&revokeEmail, "email", "e", "", "签发证书的 ACME 账户邮箱（默认使用证书记录的账户）")
+	revokeCmd.Flags().StringVar(&revokeCA, "ca", "", "CA 名称（默认使用证书记录的 CA）")
+	revokeCmd.Flags().StringVar(&revokeCAURL, "ca-url", "", "自定义 ACME 目录地址")
+	revokeCmd.Flags().BoolVar(&revokeStaging, "staging", false, "使用测试环境（默认 Let's Encrypt，配合 --ca 时为该 CA 的测试环境）")
+	revokeCmd.Flags().BoolVarP(&revokeYes, "yes", "y", false, "跳过确认")
+}
+
+// revokeTarget 待吊销的证书文件
+type revokeTarget struct {
+	CertPath string
+	KeyPath  string
+}
+
+func runRevoke(cmd *cobra.Command, args []string) error {
+	legolog.Logger = &noopLogger{}
+	fmt.Println()
+
+	if revokeDomain == "" && revokeCertPath == "" {
+		ui.ErrorWithHint(i18n.T("revoke.no_target"), []string{
+			i18n.T("revoke.usage"),
+		})
+		return nil
+	}
+
+	reason, err := acme.ParseRevocationReason(revokeReason)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	if revokeOutput == "" {
+		revokeOutput = config.Get().CertsDir
+	}
+
+	// 1. 确定要吊销的证书文件
+	var targets []revokeTarget
+	var meta *cert.Meta
+	if revokeDomain != "" {
+		certs, err := cert.ListCertificates(revokeOutput)
+		if err != nil {
+			ui.Error(fmt.Sprintf(i18n.T("revoke.scan_fail"), err))
+			return nil
+		}
+		for _, c := range certs {
+			if c.Domain == revokeDomain {
+				targets = append(targets, revokeTarget{CertPath: c.CertPath, KeyPath: c.KeyPath})
+			}
+		}
+		if len(targets) == 0 {
+			ui.Error(fmt.Sprintf(i18n.T("revoke.not_found"), revokeDomain, revokeOutput))
+			return nil
+		}
+		meta, _ = cert.LoadMeta(revokeOutput, revokeDomain)
+	} else {
+		keyPath := revokeKeyPath
+		if keyPath == "" {
+			keyPath = certKeyPath(revokeCertPath)
+		}
+		if keyPath == "" && revokeUseCertKey {
+			ui.Error(fmt.Sprintf(i18n.T("revoke.key_unknown"), revokeCertPath))
+			return nil
+		}
+		targets = append(targets, revokeTarget{CertPath: revokeCertPath, KeyPath: keyPath})
+		meta, _ = cert.LoadMeta(filepath.Dir(filepath.Dir(revokeCertPath)), filepath.Base(filepath.Dir(revokeCertPath)))
+	}
+
+	// 2. 确定 CA，默认沿用证书记录的 CA
+	caName, caURL := revokeCA, revokeCAURL
+	if caName == "" && caURL == "" && !revokeStaging && meta != nil {
+		caName, caURL = meta.CA, meta.CAURL
+	}
+	ca, err := resolveCA(caName, caURL, revokeStaging)
+	if err != nil {
+		ui.Error(err.Error())
+		return nil
+	}
+
+	ui.Title(i18n.T("revoke.title"))
+	fmt.Println()
+	for _, t := range targets {
+		ui.Detail(t.CertPath)
+	}
+	ui.Detail(fmt.Sprintf("CA: %s", ca))
+	ui.Detail(fmt.Sprintf("%s: %s", i18n.T("revoke.reason"), reasonName(reason)))
+	fmt.Println()
+
+	if !revokeYes && !ui.Confirm(i18n.T("revoke.confirm")) {
+		ui.Info(i18n.T("ui.cancelled_op"))
+		return nil
+	}
+
+	// 3. 使用账户私钥时需要加载签发证书的账户
+	var client *acme.Client
+	if !revokeUseCertKey {
+		email := selectAccount(getConfigDir(), ca, revokeEmail, metaEmail(meta))
+		account, err := acme.LoadAccount(getConfigDir(), ca.DirectoryURL, email)
+		if err != nil {
+			ui.ErrorWithHint(i18n.T("revoke.account_fail"), []string{
+				fmt.Sprintf("Error: %v", err),
+				i18n.T("revoke.hint_cert_key"),
+			})
+			return nil
+		}
+		client, err = acme.NewClient(cmd.Context(), account, ca, acme.NewManualDNSProvider(nil, nil))
+		if err != nil {
+			ui.Error(fmt.Sprintf("%s: %v", i18n.T("error.client_fail"), err))
+			return nil
+		}
+	}
+
+	// 4. 吊销，单个证书失败时继续吊销其余证书
+	var revoked []revokeTarget
+	var failed []string
+	for _, t := range targets {
+		if err := revokeFile(ca, client, t, reason); err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		revoked = append(revoked, t)
+		ui.Success(fmt.Sprintf(i18n.T("revoke.done"), t.CertPath))
+	}
+	if len(failed) > 0 {
+		ui.ErrorWithHint(i18n.T("revoke.fail"), failed)
+	}
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	// 5. 归档已吊销的证书，避免 list 继续将其显示为有效证书
+	// 全部吊销时归档整个证书目录，否则只归档已吊销的文件，未吊销的证书保留原处
+	var archived string
+	if revokeDomain != "" && len(failed) == 0 {
+		archived, err = cert.Archive(revokeOutput, revokeDomain)
+	} else {
+		var paths []string
+		for _, t := range revoked {
+			paths = append(paths, t.CertPath, t.KeyPath)
+		}
+		archived, err = cert.ArchiveFiles(paths...)
+	}
+	if err != nil {
+		ui.Warning(fmt.Sprintf(i18n.T("revoke.archive_fail"), err))
+		return nil
+	}
+	ui.Info(fmt.Sprintf(i18n.T("revoke.archived"), archived))
+	fmt.Println()
+	return nil
+}
+
+// revokeFile 吊销单个证书文件，使用证书私钥认证时 client 为 nil
+func revokeFile(ca acme.CA, client *acme.Client, t revokeTarget, reason uint) error {
+	certPEM, err := os.ReadFile(t.CertPath)
+	if err != nil {
+		return fmt.Errorf(i18n.T("revoke.read_fail"), t.CertPath, err)
+	}
+
+	if client == nil {
+		keyPEM, readErr := os.ReadFile(t.KeyPath)
+		if readErr != nil {
+			return fmt.Errorf(i18n.T("revoke.read_fail"), t.KeyPath, readErr)
+		}
+		err = acme.RevokeWithCertKey(ca, certPEM, keyPEM, reason)
+	} else {
+		err = client.RevokeCertificate(certPEM, reason)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", t.CertPath, err)
+	}
+	return nil
+}
+
+// certKeyPath 按证书目录的命名规则查找证书对应的私钥，如 fullchain.pem 对应 privkey.pem、
+// example.com.ecc.pem 对应 example.com.ecc.key；不在证书目录中时使用同名且存在的 .key 文件，找不到时返回空
+func certKeyPath(certPath string) string {
+	want, err := filepath.Abs(certPath)
+	if err != nil {
+		return ""
+	}
+	if certs, err := cert.ListCertificates(filepath.Dir(filepath.Dir(want))); err == nil {
+		for _, c := range certs {
+			if c.CertPath == want {
+				return c.KeyPath
+			}
+		}
+	}
+
+	keyPath := strings.TrimSuffix(certPath, filepath.Ext(certPath)) + ".key"
+	if _, err := os.Stat(keyPath); err != nil {
+		return ""
+	}
+	return keyPath
+}
+
+func reasonName(reason uint) string {
+	for name, code := range acme.RevocationReasons {
+		if code == reason {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", reason)
+}
@@ -11,6 +11,7 @@ import (
 	"certctl/internal/ai"
 	"certctl/internal/cert"
 	"certctl/internal/config"
+	"certctl/internal/dns"
 	"certctl/internal/i18n"
 	"certctl/internal/ui"
 
@@ -284,70 +285,39 @@ func manageDNSConfig() {
 
 		dnsConfigs := config.GetDNSConfigs()
 		if len(dnsConfigs) > 0 {
-			for _, dns := range dnsConfigs {
-				maskedKey := dns.AccessKeyID
-				if len(maskedKey) > 8 {
-					maskedKey = maskedKey[:4] + "****" + maskedKey[len(maskedKey)-4:]
-				}
-				ui.StatusLine(dns.Name, fmt.Sprintf("%s (%s)", dns.Provider, maskedKey))
+			for _, cfg := range dnsConfigs {
+				ui.StatusLine(cfg.Name, dnsConfigSummary(cfg))
 			}
 		} else {
 			ui.Info(i18n.T("ui.no_dns_config"))
 		}
 		fmt.Println()
 
-		idx, _, err := ui.Select(i18n.T("ui.select_action"), []string{
-			i18n.T("ui.add_aliyun"),
-			i18n.T("ui.add_tencentcloud"),
-			i18n.T("ui.delete_config"),
-			i18n.T("ui.back"),
-		})
+		// 每个提供商一个添加入口
+		providers := dns.Providers()
+		actions := []string{}
+		for _, p := range providers {
+			actions = append(actions, fmt.Sprintf(i18n.T("ui.add_dns_config"), p.DisplayName()))
+		}
+		actions = append(actions, i18n.T("ui.delete_config"), i18n.T("ui.back"))
 
+		idx, _, err := ui.Select(i18n.T("ui.select_action"), actions)
 		if err != nil {
 			return
 		}
 
-		switch idx {
-		case 0:
-			// 添加阿里云配置
-			addAliyunDNSConfig()
-		case 1:
-			// 添加腾讯云配置
-			addTencentCloudDNSConfig()
-		case 2:
+		switch {
+		case idx < len(providers):
+			addDNSConfig(providers[idx])
+		case idx == len(providers):
 			// 删除配置
 			deletesDNSConfig()
-		case 3:
+		default:
 			return
 		}
 	}
 }
 
-// addAliyunDNSConfig 添加阿里云 DNS 配置（支持命名）
-func addAliyunDNSConfig() {
-	// 输入配置名称
-	name, err := ui.Input(i18n.T("ui.config_name"), "")
-	if err != nil || name == "" {
-		ui.Error(i18n.T("ui.name_empty"))
-		return
-	}
-
-	accessKeyID, err := ui.Input(i18n.T("ui.ali_key_id"), "")
-	if err != nil || accessKeyID == "" {
-		ui.Error(i18n.T("ui.ali_key_empty"))
-		return
-	}
-
-	accessKeySecret, err := ui.InputSecret(i18n.T("ui.ali_secret"))
-	if err != nil || accessKeySecret == "" {
-		ui.Error(i18n.T("ui.ali_secret_empty"))
-		return
-	}
-
-	config.AddDNSConfig(name, "aliyun", accessKeyID, accessKeySecret)
-	ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-}
-
 // deletesDNSConfig 删除 DNS 配置
 func deletesDNSConfig() {
 	dnsConfigs := config.GetDNSConfigs()
@@ -357,8 +327,8 @@ func deletesDNSConfig() {
 	}
 
 	names := []string{}
-	for _, dns := range dnsConfigs {
-		names = append(names, fmt.Sprintf("%s (%s)", dns.Name, dns.Provider))
+	for _, cfg := range dnsConfigs {
+		names = append(names, fmt.Sprintf("%s (%s)", cfg.Name, cfg.Provider))
 	}
 	names = append(names, i18n.T("ui.cancel"))
 
@@ -372,31 +342,6 @@ func deletesDNSConfig() {
 	ui.Success(fmt.Sprintf(i18n.T("ui.deleted"), configName))
 }
 
-// addTencentCloudDNSConfig 添加腾讯云 DNS 配置
-func addTencentCloudDNSConfig() {
-	// 输入配置名称
-	name, err := ui.Input(i18n.T("ui.config_name"), "")
-	if err != nil || name == "" {
-		ui.Error(i18n.T("ui.name_empty"))
-		return
-	}
-
-	secretId, err := ui.Input("腾讯云 SecretId", "")
-	if err != nil || secretId == "" {
-		ui.Error("SecretId 不能为空")
-		return
-	}
-
-	secretKey, err := ui.InputSecret("腾讯云 SecretKey")
-	if err != nil || secretKey == "" {
-		ui.Error("SecretKey 不能为空")
-		return
-	}
-
-	config.AddDNSConfig(name, "tencentcloud", secretId, secretKey)
-	ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-}
-
 // setCertsDirInner 内部设置证书目录（不按键返回）
 func setCertsDirInner() {
 	var newDir string
@@ -528,12 +473,16 @@ func manageAIConfig() {
 func runApplyInteractive() {
 	ui.Header(i18n.T("ui.apply_title"))
 
-	// 1. 输入域名
-	domain, err := ui.Input(i18n.T("ui.domain"), "")
-	if err != nil || domain == "" {
+	// 1. 输入域名（多个域名用逗号分隔）
+	domainInput, err := ui.Input(i18n.T("ui.domain"), "")
+	if err != nil || domainInput == "" {
 		ui.Error(i18n.T("ui.domain_empty"))
 		return
 	}
+	domains := strings.Split(domainInput, ",")
+
+	// 是否同时申请通配符（保持原有的 根域名 + 通配符 习惯）
+	wildcard := ui.ConfirmPrompt(i18n.T("ui.wildcard_confirm"))
 
 	// 2. 输入邮箱
 	email, err := ui.Input(i18n.T("ui.email"), "")
@@ -543,146 +492,44 @@ func runApplyInteractive() {
 	}
 
 	// 3. 选择 DNS 验证方式
-	dnsIdx, _, err := ui.Select(i18n.T("ui.dns_verify_method"), []string{
-		i18n.T("ui.aliyun_auto"),
-		"腾讯云NS(自动)",
-		i18n.T("ui.manual_dns"),
-	})
+	providers := dns.Providers()
+	methods := []string{}
+	for _, p := range providers {
+		methods = append(methods, fmt.Sprintf(i18n.T("ui.dns_auto"), p.DisplayName()))
+	}
+	methods = append(methods, i18n.T("ui.manual_dns"))
+
+	dnsIdx, _, err := ui.Select(i18n.T("ui.dns_verify_method"), methods)
 	if err != nil {
 		return
 	}
 
+	// 4. 自动验证：选择已保存的配置或输入凭证
 	var dnsProvider string
-	if dnsIdx == 0 {
-		dnsProvider = "aliyun"
-	} else if dnsIdx == 1 {
-		dnsProvider = "tencentcloud"
-	}
-
-	// 4. 如果是阿里云，检查已保存的配置
-	var aliKey, aliSecret string
-	var tencentId, tencentSecret string
-	if dnsProvider == "aliyun" {
-		// 获取所有阿里云配置
-		aliyunConfigs := config.GetDNSConfigsByProvider("aliyun")
-
-		if len(aliyunConfigs) > 0 {
-			// 有已保存的配置，让用户选择
-			options := []string{}
-			for _, cfg := range aliyunConfigs {
-				maskedKey := cfg.AccessKeyID
-				if len(maskedKey) > 8 {
-					maskedKey = maskedKey[:4] + "****" + maskedKey[len(maskedKey)-4:]
-				}
-				options = append(options, fmt.Sprintf("%s (%s)", cfg.Name, maskedKey))
-			}
-			options = append(options, i18n.T("ui.input_new_config"))
-
-			cfgIdx, _, _ := ui.Select(i18n.T("ui.select_aliyun_cfg"), options)
-
-			if cfgIdx < len(aliyunConfigs) {
-				// 使用已保存的配置
-				selectedConfig := aliyunConfigs[cfgIdx]
-				aliKey = selectedConfig.AccessKeyID
-				aliSecret = selectedConfig.AccessKeySecret
-				ui.Success(fmt.Sprintf(i18n.T("ui.using_config"), selectedConfig.Name))
-			} else {
-				// 输入新配置
-				aliKey, aliSecret = inputAliyunCredentials()
-				if aliKey == "" || aliSecret == "" {
-					return
-				}
-				// 询问是否保存
-				if ui.ConfirmPrompt(i18n.T("ui.save_config_local")) {
-					name, _ := ui.Input(i18n.T("ui.config_name_new"), "")
-					if name != "" {
-						config.AddDNSConfig(name, "aliyun", aliKey, aliSecret)
-						ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-					}
-				}
-			}
-		} else {
-			// 没有保存的配置，需要输入
-			aliKey, aliSecret = inputAliyunCredentials()
-			if aliKey == "" || aliSecret == "" {
-				return
-			}
-			// 询问是否保存
-			if ui.ConfirmPrompt(i18n.T("ui.save_config_local")) {
-				name, _ := ui.Input(i18n.T("ui.config_name"), "")
-				if name != "" {
-					config.AddDNSConfig(name, "aliyun", aliKey, aliSecret)
-					ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-				}
-			}
-		}
-	} else if dnsProvider == "tencentcloud" {
-		// 获取所有腾讯云配置
-		tencentConfigs := config.GetDNSConfigsByProvider("tencentcloud")
-
-		if len(tencentConfigs) > 0 {
-			// 有已保存的配置，让用户选择
-			options := []string{}
-			for _, cfg := range tencentConfigs {
-				maskedKey := cfg.AccessKeyID
-				if len(maskedKey) > 8 {
-					maskedKey = maskedKey[:4] + "****" + maskedKey[len(maskedKey)-4:]
-				}
-				options = append(options, fmt.Sprintf("%s (%s)", cfg.Name, maskedKey))
-			}
-			options = append(options, i18n.T("ui.input_new_config"))
-
-			cfgIdx, _, _ := ui.Select("选择腾讯云配置:", options)
-
-			if cfgIdx < len(tencentConfigs) {
-				// 使用已保存的配置
-				selectedConfig := tencentConfigs[cfgIdx]
-				tencentId = selectedConfig.AccessKeyID
-				tencentSecret = selectedConfig.AccessKeySecret
-				ui.Success(fmt.Sprintf(i18n.T("ui.using_config"), selectedConfig.Name))
-			} else {
-				// 输入新配置
-				tencentId, _ = ui.Input("腾讯云 SecretId", os.Getenv("TENCENTCLOUD_SECRET_ID"))
-				tencentSecret, _ = ui.InputSecret("腾讯云 SecretKey")
-				if tencentId == "" || tencentSecret == "" {
-					return
-				}
-				// 询问是否保存
-				if ui.ConfirmPrompt(i18n.T("ui.save_config_local")) {
-					name, _ := ui.Input(i18n.T("ui.config_name_new"), "")
-					if name != "" {
-						config.AddDNSConfig(name, "tencentcloud", tencentId, tencentSecret)
-						ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-					}
-				}
-			}
-		} else {
-			// 没有保存的配置，需要输入
-			tencentId, _ = ui.Input("腾讯云 SecretId", os.Getenv("TENCENTCLOUD_SECRET_ID"))
-			tencentSecret, _ = ui.InputSecret("腾讯云 SecretKey")
-			if tencentId == "" || tencentSecret == "" {
-				return
-			}
-			// 询问是否保存
-			if ui.ConfirmPrompt(i18n.T("ui.save_config_local")) {
-				name, _ := ui.Input(i18n.T("ui.config_name"), "")
-				if name != "" {
-					config.AddDNSConfig(name, "tencentcloud", tencentId, tencentSecret)
-					ui.Success(fmt.Sprintf(i18n.T("ui.config_saved"), name))
-				}
-			}
+	var dnsCreds dns.Credentials
+	var dnsConfigName string // 使用或新保存的 DNS 配置名称，续期时沿用
+	if dnsIdx < len(providers) {
+		p := providers[dnsIdx]
+		dnsProvider = p.Name
+
+		var ok bool
+		dnsCreds, dnsConfigName, ok = selectDNSCredentials(p)
+		if !ok {
+			return
 		}
 	}
 
 	// 5. 确认
 	fmt.Println()
 	ui.Info(i18n.T("ui.will_apply"))
-	ui.Detail(fmt.Sprintf(i18n.T("ui.domain_info"), domain, domain))
+	if wildcard {
+		ui.Detail(fmt.Sprintf(i18n.T("ui.domain_info_wildcard"), strings.Join(domains, ", ")))
+	} else {
+		ui.Detail(fmt.Sprintf(i18n.T("ui.domain_info_list"), strings.Join(domains, ", ")))
+	}
 	ui.Detail(fmt.Sprintf(i18n.T("ui.email_info"), email))
-	if dnsProvider == "aliyun" {
-		ui.Detail(i18n.T("ui.dns_aliyun_auto"))
-	} else if dnsProvider == "tencentcloud" {
-		ui.Detail("DNS: 腾讯云自动验证")
+	if p, ok := dns.Get(dnsProvider); ok {
+		ui.Detail(fmt.Sprintf(i18n.T("ui.dns_provider_auto"), p.DisplayName()))
 	} else {
 		ui.Detail(i18n.T("ui.dns_manual"))
 	}
@@ -694,13 +541,13 @@ func runApplyInteractive() {
 	}
 
 	// 6. 设置参数并执行原有逻辑
-	flagDomain = domain
+	flagDomains = domains
+	flagWildcard = wildcard
 	flagEmail = email
 	flagDNS = dnsProvider
-	flagAliKey = aliKey
-	flagAliSecret = aliSecret
-	flagTencentId = tencentId
-	flagTencentSecret = tencentSecret
+	resetDNSFlags()
+	setDNSFlags(dnsProvider, dnsCreds)
+	flagDNSConfig = dnsConfigName
 	flagDryRun = false
 
 	runApply(nil, nil)
@@ -733,8 +580,8 @@ func runRenewInteractive() {
 	// 构建选择列表 - 先计算最大域名长度用于对齐
 	maxDomainLen := 0
 	for _, c := range certs {
-		if len(c.Domain) > maxDomainLen {
-			maxDomainLen = len(c.Domain)
+		if len(certLabel(c)) > maxDomainLen {
+			maxDomainLen = len(certLabel(c))
 		}
 	}
 
@@ -751,7 +598,7 @@ func runRenewInteractive() {
 		// 只显示目录，不显示文件名
 		certDir := filepath.Dir(c.CertPath)
 		// 使用固定宽度格式化域名
-		domainPadded := fmt.Sprintf("%-*s", maxDomainLen, c.Domain)
+		domainPadded := fmt.Sprintf("%-*s", maxDomainLen, certLabel(c))
 		options = append(options, fmt.Sprintf("%s  %s  %s", domainPadded, status, certDir))
 	}
 	options = append(options, i18n.T("ui.back"))
@@ -766,24 +613,16 @@ func runRenewInteractive() {
 	selectedCert := certs[idx]
 	renewDomain = selectedCert.Domain
 	renewOutput = certsDir
+	resetDNSFlags()
 	runRenew(nil, nil)
 }
 
-// inputAliyunCredentials 输入阿里云凭证
-func inputAliyunCredentials() (string, string) {
-	aliKey, err := ui.Input(i18n.T("ui.ali_key_id"), os.Getenv("ALICLOUD_ACCESS_KEY"))
-	if err != nil || aliKey == "" {
-		ui.Error(i18n.T("ui.ali_key_empty"))
-		return "", ""
+// certLabel 证书显示名称，双证书模式下附带 ECC/RSA 标识
+func certLabel(c cert.Certificate) string {
+	if c.Variant == "" {
+		return c.Domain
 	}
-
-	aliSecret, err := ui.InputSecret(i18n.T("ui.ali_secret"))
-	if err != nil || aliSecret == "" {
-		ui.Error(i18n.T("ui.ali_secret_empty"))
-		return "", ""
-	}
-
-	return aliKey, aliSecret
+	return fmt.Sprintf("%s (%s)", c.Domain, strings.ToUpper(c.Variant))
 }
 
 // noopLogger 实现 lego 的 StdLogger 接口，丢弃所有日志
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"certctl/internal/i18n"
+	"certctl/internal/schedule"
+	"certctl/internal/ui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleSystemd bool
+	scheduleCron    bool
+	scheduleDays    int
+	scheduleOutput  string
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "管理定时续期任务",
+	Long:  "生成 systemd timer 或 crontab 条目，每天执行 certctl renew --all 自动续期",
+}
+
+var scheduleInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "安装定时续期任务",
+	RunE:  runScheduleInstall,
+}
+
+var scheduleStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "查看定时续期任务",
+	RunE:  runScheduleStatus,
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "删除定时续期任务",
+	RunE:  runScheduleRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleInstallCmd, scheduleStatusCmd, scheduleRemoveCmd)
+
+	for _, c := range []*cobra.Command{scheduleInstallCmd, scheduleStatusCmd, scheduleRemoveCmd} {
+		c.Flags().BoolVar(&scheduleSystemd, "systemd", false, "使用 systemd timer")
+		c.Flags().BoolVar(&scheduleCron, "cron", false, "使用 crontab")
+	}
+	scheduleInstallCmd.Flags().IntVar(&scheduleDays, "days", 30, "剩余天数少于该值的证书才会续期")
+	scheduleInstallCmd.Flags().StringVarP(&scheduleOutput, "output", "o", "", "证书目录（默认使用配置中的证书目录）")
+}
+
+// scheduleUserMode 非 root 用户安装到 systemd 用户目录
+func scheduleUserMode() bool {
+	return os.Geteuid() != 0
+}
+
+func checkScheduleSupported() bool {
+	if runtime.GOOS == "windows" {
+		ui.Error(i18n.T("schedule.unsupported"))
+		return false
+	}
+	return true
+}
+
+func runScheduleInstall(cmd *cobra.Command, args []string) error {
+	fmt.Println()
+	if !checkScheduleSupported() {
+		return nil
+	}
+	if scheduleSystemd == scheduleCron {
+		ui.ErrorWithHint(i18n.T("schedule.choose"), []string{
+			"certctl schedule install --systemd",
+			"certctl schedule install --cron",
+		})
+		return nil
+	}
+
+	// 定时任务中使用当前可执行文件的绝对路径
+	binary, err := os.Executable()
+	if err == nil {
+		binary, err = filepath.EvalSymlinks(binary)
+	}
+	if err != nil {
+		ui.Error(fmt.Sprintf(i18n.T("schedule.exe_fail"), err))
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		ui.Error(fmt.Sprintf(i18n.T("schedule.exe_fail"), err))
+		return nil
+	}
+	if scheduleOutput != "" {
+		if scheduleOutput, err = filepath.Abs(scheduleOutput); err != nil {
+			ui.Error(err.Error())
+			return nil
+		}
+	}
+
+	opts := schedule.Options{
+		Binary:   binary,
+		Home:     home,
+		CertsDir: scheduleOutput,
+		Days:     scheduleDays,
+	}
+
+	if scheduleSystemd {
+		files, err := schedule.InstallSystemd(opts, scheduleUserMode())
+		for _, f := range files {
+			ui.Detail(f)
+		}
+		if err != nil {
+			ui.Error(fmt.Sprintf(i18n.T("schedule.install_fail"), err))
+			return nil
+		}
+		ui.Success(fmt.Sprintf(i18n.T("schedule.installed_systemd"), schedule.Name+".timer"))
+		if scheduleUserMode() {
+			ui.Info(i18n.T("schedule.linger_hint"))
+		}
+		return nil
+	}
+
+	line, err := schedule.InstallCron(opts)
+	if err != nil {
+		ui.Error(fmt.Sprintf(i18n.T("schedule.install_fail"), err))
+		return nil
+	}
+	ui.Success(i18n.T("schedule.installed_cron"))
+	ui.Detail(line)
+	return nil
+}
+
+func runScheduleStatus(cmd *cobra.Command, args []string) error {
+	fmt.Println()
+	if !checkScheduleSupported() {
+		return nil
+	}
+	both := !scheduleSystemd && !scheduleCron
+
+	if scheduleSystemd || both {
+		status, err := schedule.SystemdStatus(scheduleUserMode())
+		printScheduleStatus("systemd", status, err)
+	}
+	if scheduleCron || both {
+		status, err := schedule.CronStatus()
+		printScheduleStatus("cron", status, err)
+	}
+	return nil
+}
+
+func printScheduleStatus(kind string, status schedule.Status, err error) {
+	if err != nil {
+		ui.StatusLine(kind, fmt.Sprintf(i18n.T("schedule.status_fail"), err))
+		return
+	}
+	if !status.Installed {
+		ui.StatusLine(kind, i18n.T("schedule.not_installed"))
+		return
+	}
+	ui.StatusLine(kind, i18n.T("schedule.installed"))
+	for _, f := range status.Files {
+		ui.Detail(f)
+	}
+	if status.Detail != "" {
+		fmt.Println()
+		fmt.Println(status.Detail)
+		fmt.Println()
+	}
+}
+
+func runScheduleRemove(cmd *cobra.Command, args []string) error {
+	fmt.Println()
+	if !checkScheduleSupported() {
+		return nil
+	}
+	both := !scheduleSystemd && !scheduleCron
+
+	if scheduleSystemd || both {
+		removed, err := schedule.RemoveSystemd(scheduleUserMode())
+		for _, f := range removed {
+			ui.Detail(f)
+		}
+		if err != nil {
+			ui.Error(fmt.Sprintf(i18n.T("schedule.remove_fail"), err))
+		} else if len(removed) > 0 {
+			ui.Success(i18n.T("schedule.removed_systemd"))
+		} else if scheduleSystemd {
+			ui.Info(i18n.T("schedule.not_installed"))
+		}
+	}
+	if scheduleCron || both {
+		n, err := schedule.RemoveCron()
+		if err != nil {
+			ui.Error(fmt.Sprintf(i18n.T("schedule.remove_fail"), err))
+		} else if n > 0 {
+			ui.Success(i18n.T("schedule.removed_cron"))
+		} else if scheduleCron {
+			ui.Info(i18n.T("schedule.not_installed"))
+		}
+	}
+	return nil
+}
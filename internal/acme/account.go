@@ -8,8 +8,14 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
+	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+
+	"certctl/internal/i18n"
 
 	"github.com/go-acme/lego/v4/registration"
 )
@@ -17,11 +23,19 @@ import (
 const accountFileName = "account.json"
 const keyFileName = "account.key"
 
+// accountsDirName 账户存储目录，按 CA 和邮箱分层：accounts/<ca>/<email>/
+const accountsDirName = "accounts"
+
+// noEmailDirName 未填写邮箱的账户使用的目录名
+const noEmailDirName = "default"
+
 // Account ACME 账户
 type Account struct {
 	Email        string                 `json:"email"`
+	CA           string                 `json:"ca"` // 账户所属 CA 的目录地址
 	Registration *registration.Resource `json:"registration"`
 	KeyPath      string                 `json:"key_path"`
+	EABKeyID     string                 `json:"eab_key_id,omitempty"` // 注册时绑定的 EAB Key ID
 	key          crypto.PrivateKey
 }
 
@@ -37,14 +51,64 @@ func (a *Account) GetPrivateKey() crypto.PrivateKey {
 	return a.key
 }
 
-// LoadOrCreateAccount 加载或创建账户
-func LoadOrCreateAccount(configDir, email string) (*Account, error) {
-	if err := os.MkdirAll(configDir, 0700); err != nil {
+// AccountDir 返回指定 CA 和邮箱的账户目录
+func AccountDir(configDir, caURL, email string) string {
+	return filepath.Join(configDir, accountsDirName, caDirName(caURL), emailDirName(email))
+}
+
+// caDirName 将 CA 目录地址转换为目录名，如 acme-v02.api.letsencrypt.org_directory
+// 目录名包含路径，同一主机上的多个 ACME 目录（如 step-ca 的多个 provisioner）使用各自的账户
+func caDirName(caURL string) string {
+	u, err := url.Parse(caURL)
+	if err != nil || u.Host == "" {
+		return sanitizePathPart(caURL)
+	}
+	name := strings.ToLower(u.Host)
+	if path := strings.Trim(u.Path, "/"); path != "" {
+		name += "/" + path
+	}
+	return sanitizePathPart(name)
+}
+
+// sameCA 判断两个 CA 目录地址是否相同，忽略末尾的斜杠和主机名大小写
+func sameCA(a, b string) bool {
+	return normalizeCAURL(a) == normalizeCAURL(b)
+}
+
+func normalizeCAURL(caURL string) string {
+	u, err := url.Parse(strings.TrimSuffix(caURL, "/"))
+	if err != nil {
+		return caURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	return u.String()
+}
+
+func emailDirName(email string) string {
+	if email == "" {
+		return noEmailDirName
+	}
+	return sanitizePathPart(strings.ToLower(email))
+}
+
+func sanitizePathPart(s string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(s)
+}
+
+// LoadOrCreateAccount 加载或创建指定 CA 下的账户
+func LoadOrCreateAccount(configDir, caURL, email string) (*Account, error) {
+	if err := migrateAccounts(configDir); err != nil {
 		return nil, err
 	}
 
-	accountPath := filepath.Join(configDir, accountFileName)
-	keyPath := filepath.Join(configDir, keyFileName)
+	dir := AccountDir(configDir, caURL, email)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	accountPath := filepath.Join(dir, accountFileName)
+	keyPath := filepath.Join(dir, keyFileName)
 
 	// 尝试加载已有账户
 	if _, err := os.Stat(accountPath); err == nil {
@@ -52,7 +116,62 @@ func LoadOrCreateAccount(configDir, email string) (*Account, error) {
 	}
 
 	// 创建新账户
-	return createAccount(configDir, email)
+	return createAccount(dir, caURL, email)
+}
+
+// LoadAccount 加载已存在的账户
+func LoadAccount(configDir, caURL, email string) (*Account, error) {
+	if err := migrateAccounts(configDir); err != nil {
+		return nil, err
+	}
+
+	dir := AccountDir(configDir, caURL, email)
+	accountPath := filepath.Join(dir, accountFileName)
+	if _, err := os.Stat(accountPath); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.account_not_found"), email, caURL)
+	}
+	return loadAccount(accountPath, filepath.Join(dir, keyFileName))
+}
+
+// ListAccounts 列出所有已保存的账户，caURL 不为空时只返回该 CA 下的账户
+func ListAccounts(configDir, caURL string) ([]*Account, error) {
+	if err := migrateAccounts(configDir); err != nil {
+		return nil, err
+	}
+
+	pattern := filepath.Join(configDir, accountsDirName, "*", "*", accountFileName)
+	if caURL != "" {
+		pattern = filepath.Join(configDir, accountsDirName, caDirName(caURL), "*", accountFileName)
+	}
+
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	var accounts []*Account
+	for _, accountPath := range paths {
+		account, err := loadAccount(accountPath, filepath.Join(filepath.Dir(accountPath), keyFileName))
+		if err != nil {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+// DeleteAccount 删除本地保存的账户（不会在 CA 侧注销）
+func DeleteAccount(configDir, caURL, email string) error {
+	if err := migrateAccounts(configDir); err != nil {
+		return err
+	}
+
+	dir := AccountDir(configDir, caURL, email)
+	if _, err := os.Stat(filepath.Join(dir, accountFileName)); err != nil {
+		return fmt.Errorf(i18n.T("error.account_not_found"), email, caURL)
+	}
+	return os.RemoveAll(dir)
 }
 
 func loadAccount(accountPath, keyPath string) (*Account, error) {
@@ -72,28 +191,33 @@ func loadAccount(accountPath, keyPath string) (*Account, error) {
 	}
 
 	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return nil, fmt.Errorf(i18n.T("error.account_key"), keyPath)
+	}
 	key, err := x509.ParseECPrivateKey(block.Bytes)
 	if err != nil {
 		return nil, err
 	}
 
+	account.KeyPath = keyPath
 	account.key = key
 	return &account, nil
 }
 
-func createAccount(configDir, email string) (*Account, error) {
+func createAccount(dir, caURL, email string) (*Account, error) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
 		return nil, err
 	}
 
-	keyPath := filepath.Join(configDir, keyFileName)
+	keyPath := filepath.Join(dir, keyFileName)
 	if err := savePrivateKey(keyPath, privateKey); err != nil {
 		return nil, err
 	}
 
 	account := &Account{
 		Email:   email,
+		CA:      caURL,
 		KeyPath: keyPath,
 		key:     privateKey,
 	}
@@ -122,6 +246,115 @@ func SaveAccount(configDir string, account *Account) error {
 		return err
 	}
 
-	accountPath := filepath.Join(configDir, accountFileName)
+	dir := AccountDir(configDir, account.CA, account.Email)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	accountPath := filepath.Join(dir, accountFileName)
 	return os.WriteFile(accountPath, data, 0600)
 }
+
+// migrateAccounts 迁移旧版本保存的账户
+func migrateAccounts(configDir string) error {
+	if err := migrateLegacyAccount(configDir); err != nil {
+		return err
+	}
+	return migrateAccountDirs(configDir)
+}
+
+// migrateLegacyAccount 将旧版 ~/.certctl/account.json 迁移到按 CA 和邮箱分层的目录
+// 旧账户的 CA 由注册地址推断，未注册成功的旧账户直接丢弃；
+// 无法读取时保留文件并返回错误，避免删除唯一的账户私钥
+func migrateLegacyAccount(configDir string) error {
+	legacyAccount := filepath.Join(configDir, accountFileName)
+	legacyKey := filepath.Join(configDir, keyFileName)
+
+	if _, err := os.Stat(legacyAccount); err != nil {
+		return nil
+	}
+
+	account, err := loadAccount(legacyAccount, legacyKey)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.account_legacy"), legacyAccount, err)
+	}
+	if account.Registration == nil {
+		os.Remove(legacyAccount)
+		os.Remove(legacyKey)
+		return nil
+	}
+
+	account.CA = legacyCAURL(account.Registration.URI)
+	dir := AccountDir(configDir, account.CA, account.Email)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// 目标位置已有账户时保留新账户
+	if _, err := os.Stat(filepath.Join(dir, accountFileName)); err == nil {
+		os.Remove(legacyAccount)
+		os.Remove(legacyKey)
+		return nil
+	}
+
+	if err := os.Rename(legacyKey, filepath.Join(dir, keyFileName)); err != nil {
+		return err
+	}
+	account.KeyPath = filepath.Join(dir, keyFileName)
+	if err := SaveAccount(configDir, account); err != nil {
+		return err
+	}
+	return os.Remove(legacyAccount)
+}
+
+// migrateAccountDirs 将旧版本按 CA 主机名保存的账户移动到 AccountDir 对应的目录
+// 目标位置已有账户时保持原样
+func migrateAccountDirs(configDir string) error {
+	paths, err := filepath.Glob(filepath.Join(configDir, accountsDirName, "*", "*", accountFileName))
+	if err != nil {
+		return err
+	}
+
+	for _, accountPath := range paths {
+		data, err := os.ReadFile(accountPath)
+		if err != nil {
+			continue
+		}
+		var account Account
+		if err := json.Unmarshal(data, &account); err != nil || account.CA == "" {
+			continue
+		}
+
+		dir := filepath.Dir(accountPath)
+		target := AccountDir(configDir, account.CA, account.Email)
+		if dir == target {
+			continue
+		}
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return err
+		}
+		if err := os.Rename(dir, target); err != nil {
+			return err
+		}
+		// 主机名目录下没有其他账户时一并删除
+		os.Remove(filepath.Dir(dir))
+	}
+	return nil
+}
+
+// legacyCAURL 根据账户注册地址推断 CA 目录地址
+func legacyCAURL(registrationURI string) string {
+	u, err := url.Parse(registrationURI)
+	if err != nil || u.Host == "" {
+		return LetsEncryptProduction
+	}
+	for _, dirURL := range KnownCAs {
+		if known, err := url.Parse(dirURL); err == nil && known.Host == u.Host {
+			return dirURL
+		}
+	}
+	return u.Scheme + "://" + u.Host
+}
@@ -0,0 +1,72 @@
+package acme
+
+import (
+	"fmt"
+	"sort"
+
+	"certctl/internal/i18n"
+)
+
+// CA ACME 证书颁发机构
+type CA struct {
+	Name         string // 名称，如 letsencrypt、zerossl
+	DirectoryURL string // ACME 目录地址
+	EABKeyID     string // External Account Binding Key ID（ZeroSSL、Google 等需要）
+	EABHMACKey   string // External Account Binding HMAC Key（base64url）
+}
+
+// DefaultCAName 默认 CA
+const DefaultCAName = "letsencrypt"
+
+// KnownCAs 内置的公共 CA 目录地址
+var KnownCAs = map[string]string{
+	"letsencrypt":         LetsEncryptProduction,
+	"letsencrypt-staging": LetsEncryptStaging,
+	"zerossl":             "https://acme.zerossl.com/v2/DV90",
+	"buypass":             "https://api.buypass.com/acme/directory",
+	"buypass-staging":     "https://api.test4.buypass.no/acme/directory",
+	"google":              "https://dv.acme-v02.api.pki.goog/directory",
+	"google-staging":      "https://dv.acme-v02.test-api.pki.goog/directory",
+}
+
+// LookupCA 根据名称查找内置 CA
+func LookupCA(name string) (CA, bool) {
+	url, ok := KnownCAs[name]
+	if !ok {
+		return CA{}, false
+	}
+	return CA{Name: name, DirectoryURL: url}, true
+}
+
+// KnownCANames 返回排序后的内置 CA 名称
+func KnownCANames() []string {
+	names := make([]string, 0, len(KnownCAs))
+	for name := range KnownCAs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String 返回 CA 的可读描述
+func (c CA) String() string {
+	if c.Name == "" {
+		return c.DirectoryURL
+	}
+	return fmt.Sprintf("%s (%s)", c.Name, c.DirectoryURL)
+}
+
+// HasEAB 是否配置了 External Account Binding
+func (c CA) HasEAB() bool {
+	return c.EABKeyID != "" && c.EABHMACKey != ""
+}
+
+func (c CA) validate() error {
+	if c.DirectoryURL == "" {
+		return fmt.Errorf(i18n.T("error.ca_url_empty"))
+	}
+	if (c.EABKeyID == "") != (c.EABHMACKey == "") {
+		return fmt.Errorf(i18n.T("error.eab_incomplete"))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package acme
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"certctl/internal/dns"
+	"certctl/internal/i18n"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// cleanupTimeout 清理验证记录的超时时间，清理不受申请过程取消的影响
+const cleanupTimeout = 30 * time.Second
+
+// InterruptedError 申请过程被取消，已添加的验证记录已尝试清理
+type InterruptedError struct {
+	Err     error    // ctx.Err()
+	Cleaned []string // 已清理的验证记录名，CNAME 委托时为委托目标
+	Failed  []error  // 清理失败的记录，需要手动删除
+}
+
+func (e *InterruptedError) Error() string {
+	return i18n.T("error.interrupted")
+}
+
+func (e *InterruptedError) Unwrap() error {
+	return e.Err
+}
+
+// contextTransport 为 lego 的 ACME 请求附加 context，取消时中止进行中的请求
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// presentedChallenge 已添加的验证记录
+type presentedChallenge struct {
+	domain  string
+	token   string
+	keyAuth string
+}
+
+// challengeTracker 包装 challenge.Provider，记录已添加的验证记录
+// lego 的 Present/CleanUp 不带 context，由 tracker 传入申请过程的 ctx；
+// 清理使用独立的 context，申请被取消后仍能删除记录
+type challengeTracker struct {
+	ctx      context.Context
+	provider challenge.Provider
+
+	mu        sync.Mutex
+	presented map[string]presentedChallenge // domain + token → 验证记录
+	cleaned   []string
+	failed    []error
+}
+
+func newChallengeTracker(ctx context.Context, provider challenge.Provider) *challengeTracker {
+	return &challengeTracker{
+		ctx:       ctx,
+		provider:  provider,
+		presented: map[string]presentedChallenge{},
+	}
+}
+
+func (t *challengeTracker) Present(domain, token, keyAuth string) error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+
+	// 先登记再添加，请求中途被取消时记录可能已经生效，同样需要清理
+	t.mu.Lock()
+	t.presented[domain+" "+token] = presentedChallenge{domain: domain, token: token, keyAuth: keyAuth}
+	t.mu.Unlock()
+
+	if p, ok := t.provider.(dns.ContextProvider); ok {
+		return p.PresentContext(t.ctx, domain, token, keyAuth)
+	}
+	return t.provider.Present(domain, token, keyAuth)
+}
+
+func (t *challengeTracker) CleanUp(domain, token, keyAuth string) error {
+	t.mu.Lock()
+	c, ok := t.presented[domain+" "+token]
+	delete(t.presented, domain+" "+token)
+	t.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return t.cleanUp(c)
+}
+
+// cleanUp 删除一条验证记录并记录结果
+func (t *challengeTracker) cleanUp(c presentedChallenge) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+
+	// 验证记录名通过 CNAME 委托时，提供者写入的是委托目标，按同样的规则解析后报告
+	record := dns.ResolveCNAME(ctx, dns.ChallengeFQDN(c.domain))
+
+	var err error
+	if p, ok := t.provider.(dns.ContextProvider); ok {
+		err = p.CleanUpContext(ctx, c.domain, c.token, c.keyAuth)
+	} else {
+		err = t.provider.CleanUp(c.domain, c.token, c.keyAuth)
+	}
+
+	t.mu.Lock()
+	if err != nil {
+		t.failed = append(t.failed, fmt.Errorf("%s: %v", record, err))
+	} else {
+		t.cleaned = append(t.cleaned, record)
+	}
+	t.mu.Unlock()
+	return err
+}
+
+// cleanUpAll 清理 lego 未清理的验证记录，申请被取消时 lego 可能没有走到清理步骤
+func (t *challengeTracker) cleanUpAll() {
+	t.mu.Lock()
+	remaining := make([]presentedChallenge, 0, len(t.presented))
+	for key, c := range t.presented {
+		remaining = append(remaining, c)
+		delete(t.presented, key)
+	}
+	t.mu.Unlock()
+
+	for _, c := range remaining {
+		t.cleanUp(c)
+	}
+}
+
+// reset 清空上一次申请的清理结果
+func (t *challengeTracker) reset() {
+	t.mu.Lock()
+	t.cleaned, t.failed = nil, nil
+	t.mu.Unlock()
+}
+
+// interrupted 返回取消时的清理结果
+func (t *challengeTracker) interrupted() *InterruptedError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// 通配符与根域名共用同一验证记录，只列出一次
+	var cleaned []string
+	seen := map[string]bool{}
+	for _, record := range t.cleaned {
+		if !seen[record] {
+			seen[record] = true
+			cleaned = append(cleaned, record)
+		}
+	}
+	return &InterruptedError{
+		Err:     t.ctx.Err(),
+		Cleaned: cleaned,
+		Failed:  append([]error(nil), t.failed...),
+	}
+}
+
+// 确保实现了接口
+var _ challenge.Provider = (*challengeTracker)(nil)
@@ -7,5 +7,6 @@ type Certificate struct {
 	Domain      string
 	Certificate []byte
 	PrivateKey  []byte
+	KeyType     KeyType
 	NotAfter    time.Time
 }
@@ -1,34 +1,36 @@
 package acme
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
-	"fmt"
 	"sync"
 
-	"github.com/go-acme/lego/v4/challenge"
+	"certctl/internal/dns"
+
 	"github.com/go-acme/lego/v4/challenge/dns01"
 )
 
 // Challenge DNS 验证挑战信息
 type Challenge struct {
 	Domain     string // 原始域名
-	FQDN       string // 完整记录名 _acme-challenge.example.com
+	FQDN       string // 完整记录名 _acme-challenge.example.com，CNAME 委托时为委托目标
 	RecordName string // 主机记录 _acme-challenge
 	Value      string // TXT 记录值
+	Alias      string // CNAME 委托时的原验证记录名，未委托时为空
 }
 
 // ManualDNSProvider 手动 DNS 验证提供者
 type ManualDNSProvider struct {
 	challenges map[string]*Challenge
 	mu         sync.Mutex
-	onPresent  func(*Challenge) error // 阻塞回调：显示信息、等待用户确认、检查 DNS
-	onCleanup  func(*Challenge) error
+	onPresent  func(context.Context, *Challenge) error // 阻塞回调：显示信息、等待用户确认、检查 DNS
+	onCleanup  func(context.Context, *Challenge) error
 }
 
 // NewManualDNSProvider 创建手动 DNS 提供者
-// onPresent 回调应该阻塞直到 DNS 记录验证通过
-func NewManualDNSProvider(onPresent, onCleanup func(*Challenge) error) *ManualDNSProvider {
+// onPresent 回调应该阻塞直到 DNS 记录验证通过，ctx 取消时应尽快返回
+func NewManualDNSProvider(onPresent, onCleanup func(context.Context, *Challenge) error) *ManualDNSProvider {
 	return &ManualDNSProvider{
 		challenges: make(map[string]*Challenge),
 		onPresent:  onPresent,
@@ -37,18 +39,37 @@ func NewManualDNSProvider(onPresent, onCleanup func(*Challenge) error) *ManualDN
 }
 
 func (p *ManualDNSProvider) Present(domain, token, keyAuth string) error {
+	return p.PresentContext(context.Background(), domain, token, keyAuth)
+}
+
+func (p *ManualDNSProvider) CleanUp(domain, token, keyAuth string) error {
+	return p.CleanUpContext(context.Background(), domain, token, keyAuth)
+}
+
+// PresentContext 显示需要添加的记录，阻塞直到记录生效
+func (p *ManualDNSProvider) PresentContext(ctx context.Context, domain, token, keyAuth string) error {
 	// 计算 TXT 记录值
 	hash := sha256.Sum256([]byte(keyAuth))
 	txtValue := base64.RawURLEncoding.EncodeToString(hash[:])
 
-	fqdn := fmt.Sprintf("_acme-challenge.%s", domain)
+	// 验证记录名通过 CNAME 委托时，TXT 记录需要添加到委托目标上
+	name := dns.ChallengeFQDN(domain)
+	fqdn := dns.ResolveCNAME(ctx, name)
+
+	recordName := "_acme-challenge"
+	if _, rr, err := dns.SplitRecord(ctx, fqdn); err == nil {
+		recordName = rr
+	}
 
 	challenge := &Challenge{
 		Domain:     domain,
 		FQDN:       fqdn,
-		RecordName: "_acme-challenge",
+		RecordName: recordName,
 		Value:      txtValue,
 	}
+	if fqdn != name {
+		challenge.Alias = name
+	}
 
 	p.mu.Lock()
 	p.challenges[domain] = challenge
@@ -56,20 +77,21 @@ func (p *ManualDNSProvider) Present(domain, token, keyAuth string) error {
 
 	// onPresent 回调会阻塞直到用户确认且 DNS 记录验证通过
 	if p.onPresent != nil {
-		return p.onPresent(challenge)
+		return p.onPresent(ctx, challenge)
 	}
 
 	return nil
 }
 
-func (p *ManualDNSProvider) CleanUp(domain, token, keyAuth string) error {
+// CleanUpContext 记录清理回调
+func (p *ManualDNSProvider) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
 	p.mu.Lock()
 	challenge := p.challenges[domain]
 	delete(p.challenges, domain)
 	p.mu.Unlock()
 
 	if p.onCleanup != nil && challenge != nil {
-		return p.onCleanup(challenge)
+		return p.onCleanup(ctx, challenge)
 	}
 
 	return nil
@@ -83,7 +105,7 @@ func (p *ManualDNSProvider) GetChallenge(domain string) *Challenge {
 }
 
 // 确保实现了接口
-var _ challenge.Provider = (*ManualDNSProvider)(nil)
+var _ dns.ContextProvider = (*ManualDNSProvider)(nil)
 
 // GetChallengeInfo 从 lego 获取挑战信息（用于调试）
 func GetChallengeInfo(domain, keyAuth string) (fqdn, value string) {
@@ -1,10 +1,13 @@
 package acme
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"time"
 
+	"certctl/internal/dns"
 	"certctl/internal/i18n"
 
 	"github.com/go-acme/lego/v4/certificate"
@@ -23,65 +26,143 @@ const (
 
 // Client ACME 客户端
 type Client struct {
-	account  *Account
-	client   *lego.Client
-	provider challenge.Provider
+	ctx        context.Context
+	account    *Account
+	ca         CA
+	client     *lego.Client
+	provider   challenge.Provider
+	challenges *challengeTracker
 }
 
 // NewClient 创建 ACME 客户端
-func NewClient(account *Account, staging bool, provider challenge.Provider) (*Client, error) {
-	config := lego.NewConfig(account)
+// lego 不支持 context，ctx 在创建时传入：取消后进行中的 ACME 请求和 DNS 检查立即中止，
+// 已添加的验证记录会被清理，ObtainCertificate 返回 *InterruptedError
+func NewClient(ctx context.Context, account *Account, ca CA, provider challenge.Provider) (*Client, error) {
+	if err := ca.validate(); err != nil {
+		return nil, err
+	}
 
-	if staging {
-		config.CADirURL = LetsEncryptStaging
-	} else {
-		config.CADirURL = LetsEncryptProduction
+	// 账户只能在注册它的 CA 上使用，防止测试环境账户被发往生产环境
+	if account.CA != "" && !sameCA(account.CA, ca.DirectoryURL) {
+		return nil, fmt.Errorf(i18n.T("error.account_ca_mismatch"), account.Email, account.CA, ca.DirectoryURL)
 	}
 
+	config := lego.NewConfig(account)
+	config.CADirURL = ca.DirectoryURL
+	config.HTTPClient.Transport = &contextTransport{ctx: ctx, base: config.HTTPClient.Transport}
+
 	client, err := lego.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.client_create"), err)
 	}
 
-	// 设置 DNS 验证，禁用 lego 自带的传播检查（我们自己检查）
+	// 设置 DNS 验证，传播检查替换为逐个查询权威服务器（与手动验证使用同一检查）
+	// 轮询、退避和超时由 WaitForPropagation 完成，lego 只调用一次
+	opts := dns.GetCheckOptions()
+	challengeOpts := []dns01.ChallengeOption{
+		dns01.WrapPreCheck(func(domain, fqdn, value string, _ dns01.PreCheckFunc) (bool, error) {
+			err := dns.WaitForPropagation(ctx, fqdn, value)
+			// lego 只有返回 true 才会停止轮询，返回错误会一直重试到超时；
+			// 取消时返回 true，随后的验证请求带有已取消的 ctx 不会发出，lego 随即清理记录并返回错误
+			if ctx.Err() != nil {
+				return true, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			return true, nil
+		}),
+	}
+	// lego 只支持普通 DNS 解析器，DoH/DoT 解析器只用于我们自己的检查
+	var nameservers []string
+	for _, r := range opts.Resolvers {
+		if dns.IsPlainResolver(r) {
+			nameservers = append(nameservers, r)
+		}
+	}
+	if len(nameservers) > 0 {
+		challengeOpts = append(challengeOpts, dns01.AddRecursiveNameservers(nameservers))
+	}
+	challenges := newChallengeTracker(ctx, provider)
 	if err := client.Challenge.SetDNS01Provider(
-		provider,
-		dns01.AddRecursiveNameservers([]string{"8.8.8.8:53", "1.1.1.1:53"}),
-		dns01.DisableCompletePropagationRequirement(),
+		&timeoutProvider{Provider: challenges, timeout: opts.Timeout, interval: time.Second},
+		challengeOpts...,
 	); err != nil {
 		return nil, fmt.Errorf(i18n.T("error.dns_provider"), err)
 	}
 
 	return &Client{
-		account:  account,
-		client:   client,
-		provider: provider,
+		ctx:        ctx,
+		account:    account,
+		ca:         ca,
+		client:     client,
+		provider:   provider,
+		challenges: challenges,
 	}, nil
 }
 
+// timeoutProvider 为 lego 提供与传播检查一致的超时时间
+type timeoutProvider struct {
+	challenge.Provider
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func (p *timeoutProvider) Timeout() (timeout, interval time.Duration) {
+	return p.timeout, p.interval
+}
+
 // Register 注册账户
 func (c *Client) Register() error {
 	if c.account.Registration != nil {
 		return nil
 	}
 
-	reg, err := c.client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	var reg *registration.Resource
+	var err error
+	if c.ca.HasEAB() {
+		reg, err = c.client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+			TermsOfServiceAgreed: true,
+			Kid:                  c.ca.EABKeyID,
+			HmacEncoded:          c.ca.EABHMACKey,
+		})
+	} else {
+		if c.client.GetExternalAccountRequired() {
+			return fmt.Errorf(i18n.T("error.eab_required"), c.ca.Name)
+		}
+		reg, err = c.client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	}
 	if err != nil {
+		if c.ctx.Err() != nil {
+			return c.challenges.interrupted()
+		}
 		return fmt.Errorf(i18n.T("error.register"), err)
 	}
 
 	c.account.Registration = reg
+	c.account.EABKeyID = c.ca.EABKeyID
 	return nil
 }
 
-// ObtainCertificate 申请证书
-func (c *Client) ObtainCertificate(domains []string) (*Certificate, error) {
+// ObtainCertificate 申请证书，使用指定类型的新私钥
+func (c *Client) ObtainCertificate(domains []string, keyType KeyType) (*Certificate, error) {
+	privateKey, err := keyType.generateKey()
+	if err != nil {
+		return nil, err
+	}
+
 	request := certificate.ObtainRequest{
-		Domains: domains,
-		Bundle:  true,
+		Domains:    domains,
+		Bundle:     true,
+		PrivateKey: privateKey,
 	}
 
+	c.challenges.reset()
 	certificates, err := c.client.Certificate.Obtain(request)
+	c.challenges.cleanUpAll()
+	if c.ctx.Err() != nil {
+		return nil, c.challenges.interrupted()
+	}
 	if err != nil {
 		return nil, fmt.Errorf(i18n.T("error.cert_obtain"), err)
 	}
@@ -101,10 +182,16 @@ func (c *Client) ObtainCertificate(domains []string) (*Certificate, error) {
 		Domain:      certificates.Domain,
 		Certificate: certificates.Certificate,
 		PrivateKey:  certificates.PrivateKey,
+		KeyType:     keyType,
 		NotAfter:    cert.NotAfter,
 	}, nil
 }
 
+// GetCA 获取当前使用的 CA
+func (c *Client) GetCA() CA {
+	return c.ca
+}
+
 // GetProvider 获取 DNS 提供者
 func (c *Client) GetProvider() challenge.Provider {
 	return c.provider
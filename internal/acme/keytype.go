@@ -0,0 +1,92 @@
+package acme
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"certctl/internal/i18n"
+)
+
+// KeyType 证书私钥类型
+type KeyType string
+
+const (
+	KeyRSA2048 KeyType = "rsa2048"
+	KeyRSA3072 KeyType = "rsa3072"
+	KeyRSA4096 KeyType = "rsa4096"
+	KeyEC256   KeyType = "ec256"
+	KeyEC384   KeyType = "ec384"
+)
+
+// DefaultKeyType 默认私钥类型，与 lego 默认值保持一致
+const DefaultKeyType = KeyRSA2048
+
+// KeyTypes 支持的私钥类型
+var KeyTypes = []KeyType{KeyRSA2048, KeyRSA3072, KeyRSA4096, KeyEC256, KeyEC384}
+
+// ParseKeyType 解析私钥类型，空字符串返回默认值
+func ParseKeyType(s string) (KeyType, error) {
+	if s == "" {
+		return DefaultKeyType, nil
+	}
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, kt := range KeyTypes {
+		if string(kt) == s {
+			return kt, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("error.key_type"), s, KeyTypeNames())
+}
+
+// KeyTypeNames 返回所有支持的私钥类型，逗号分隔
+func KeyTypeNames() string {
+	names := make([]string, 0, len(KeyTypes))
+	for _, kt := range KeyTypes {
+		names = append(names, string(kt))
+	}
+	return strings.Join(names, ", ")
+}
+
+// IsRSA 是否为 RSA 私钥
+func (k KeyType) IsRSA() bool {
+	return strings.HasPrefix(string(k), "rsa")
+}
+
+// IsEC 是否为 ECDSA 私钥
+func (k KeyType) IsEC() bool {
+	return strings.HasPrefix(string(k), "ec")
+}
+
+// generateKey 生成对应类型的私钥
+func (k KeyType) generateKey() (crypto.PrivateKey, error) {
+	switch k {
+	case KeyRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case KeyRSA3072:
+		return rsa.GenerateKey(rand.Reader, 3072)
+	case KeyRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case KeyEC256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyEC384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	}
+	return nil, fmt.Errorf(i18n.T("error.key_type"), k, KeyTypeNames())
+}
+
+// DualKeyTypes 返回双证书模式下的 ECDSA 与 RSA 私钥类型
+// 指定的类型会替换同类的默认值，如 rsa4096 → (ec256, rsa4096)
+func DualKeyTypes(k KeyType) (ecType, rsaType KeyType) {
+	ecType, rsaType = KeyEC256, KeyRSA2048
+	if k.IsEC() {
+		ecType = k
+	} else if k.IsRSA() {
+		rsaType = k
+	}
+	return ecType, rsaType
+}
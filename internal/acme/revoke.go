@@ -0,0 +1,89 @@
+package acme
+
+import (
+	"crypto"
+	"fmt"
+	"sort"
+
+	"certctl/internal/i18n"
+
+	legoacme "github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/registration"
+)
+
+// RevocationReasons RFC 5280 吊销原因
+var RevocationReasons = map[string]uint{
+	"unspecified":          legoacme.CRLReasonUnspecified,
+	"keyCompromise":        legoacme.CRLReasonKeyCompromise,
+	"affiliationChanged":   legoacme.CRLReasonAffiliationChanged,
+	"superseded":           legoacme.CRLReasonSuperseded,
+	"cessationOfOperation": legoacme.CRLReasonCessationOfOperation,
+}
+
+// ParseRevocationReason 解析吊销原因，空字符串视为 unspecified
+func ParseRevocationReason(s string) (uint, error) {
+	if s == "" {
+		return legoacme.CRLReasonUnspecified, nil
+	}
+	if reason, ok := RevocationReasons[s]; ok {
+		return reason, nil
+	}
+	return 0, fmt.Errorf(i18n.T("error.revoke_reason"), s, RevocationReasonNames())
+}
+
+// RevocationReasonNames 返回所有支持的吊销原因
+func RevocationReasonNames() []string {
+	names := make([]string, 0, len(RevocationReasons))
+	for name := range RevocationReasons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RevokeCertificate 使用账户私钥吊销证书，账户必须是签发该证书的账户
+func (c *Client) RevokeCertificate(certPEM []byte, reason uint) error {
+	if c.account.Registration == nil {
+		return fmt.Errorf(i18n.T("error.revoke_unregistered"))
+	}
+	if err := c.client.Certificate.RevokeWithReason(certPEM, &reason); err != nil {
+		return fmt.Errorf(i18n.T("error.revoke"), err)
+	}
+	return nil
+}
+
+// certKeyUser 以证书私钥签名请求的临时用户，不对应任何 ACME 账户
+type certKeyUser struct {
+	key crypto.PrivateKey
+}
+
+func (u *certKeyUser) GetEmail() string                        { return "" }
+func (u *certKeyUser) GetRegistration() *registration.Resource { return nil }
+func (u *certKeyUser) GetPrivateKey() crypto.PrivateKey        { return u.key }
+
+// RevokeWithCertKey 使用证书自身的私钥吊销证书，适用于账户丢失或私钥泄露的情况
+func RevokeWithCertKey(ca CA, certPEM, keyPEM []byte, reason uint) error {
+	if err := ca.validate(); err != nil {
+		return err
+	}
+
+	key, err := certcrypto.ParsePEMPrivateKey(keyPEM)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.revoke_key"), err)
+	}
+
+	config := lego.NewConfig(&certKeyUser{key: key})
+	config.CADirURL = ca.DirectoryURL
+
+	client, err := lego.NewClient(config)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.client_create"), err)
+	}
+
+	if err := client.Certificate.RevokeWithReason(certPEM, &reason); err != nil {
+		return fmt.Errorf(i18n.T("error.revoke"), err)
+	}
+	return nil
+}
@@ -59,8 +59,9 @@ func NewZhipuClient() *ZhipuClient {
 }
 
 // Diagnose 诊断证书申请错误
-func (c *ZhipuClient) Diagnose(errorMsg, domain, dnsProvider string) (string, error) {
-	prompt := buildDiagnosisPrompt(errorMsg, domain, dnsProvider)
+// propagation 为 DNS 传播检查结果，没有时为空
+func (c *ZhipuClient) Diagnose(errorMsg, domain, dnsProvider, propagation string) (string, error) {
+	prompt := buildDiagnosisPrompt(errorMsg, domain, dnsProvider, propagation)
 
 	req := ZhipuRequest{
 		Model: c.Model,
@@ -141,7 +142,7 @@ func (c *ZhipuClient) TestConnection() error {
 }
 
 // buildDiagnosisPrompt 构建诊断提示词
-func buildDiagnosisPrompt(errorMsg, domain, dnsProvider string) string {
+func buildDiagnosisPrompt(errorMsg, domain, dnsProvider, propagation string) string {
 	lang := i18n.Lang
 	
 	if lang == "en" {
@@ -152,7 +153,7 @@ Error message:
 
 Domain: %s
 DNS Provider: %s
-
+%s
 Please answer concisely in English with the following format:
 
 🔍 Problem: (one sentence describing the issue)
@@ -161,7 +162,7 @@ Please answer concisely in English with the following format:
 1. xxx
 2. xxx
 
-💡 Retry recommended: Yes/No`, errorMsg, domain, dnsProvider)
+💡 Retry recommended: Yes/No`, errorMsg, domain, dnsProvider, propagationSection(propagation, "DNS propagation check (per nameserver/resolver):"))
 	}
 	
 	// 默认中文
@@ -172,7 +173,7 @@ Please answer concisely in English with the following format:
 
 域名: %s
 DNS 提供商: %s
-
+%s
 请用简洁的中文回答，格式如下：
 
 🔍 问题原因：（一句话描述问题）
@@ -183,14 +184,22 @@ DNS 提供商: %s
 3. xxx
 ..........
 
-💡 是否建议重试：是/否`, errorMsg, domain, dnsProvider)
+💡 是否建议重试：是/否`, errorMsg, domain, dnsProvider, propagationSection(propagation, "DNS 传播检查结果（逐个权威服务器/递归解析器）:"))
+}
+
+// propagationSection 生成提示词中的传播检查部分，没有结果时为空
+func propagationSection(propagation, title string) string {
+	if propagation == "" {
+		return ""
+	}
+	return fmt.Sprintf("\n%s\n%s\n", title, propagation)
 }
 
 // DiagnoseError 便捷函数：诊断错误
-func DiagnoseError(errorMsg, domain, dnsProvider string) (string, error) {
+func DiagnoseError(errorMsg, domain, dnsProvider, propagation string) (string, error) {
 	if !config.IsAIEnabled() {
 		return "", fmt.Errorf(i18n.T("error.ai_disabled"))
 	}
 	client := NewZhipuClient()
-	return client.Diagnose(errorMsg, domain, dnsProvider)
+	return client.Diagnose(errorMsg, domain, dnsProvider, propagation)
 }
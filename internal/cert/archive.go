@@ -0,0 +1,49 @@
+package cert
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ArchiveDirName 已吊销证书的归档目录，ListCertificates 会跳过以 . 开头的目录
+const ArchiveDirName = ".archive"
+
+// Archive 将整个证书目录移动到 <certsDir>/.archive/<name>-<时间戳>
+func Archive(certsDir, name string) (string, error) {
+	archiveDir := filepath.Join(certsDir, ArchiveDirName)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return "", err
+	}
+
+	dest := filepath.Join(archiveDir, name+"-"+time.Now().Format("20060102150405"))
+	if err := os.Rename(filepath.Join(certsDir, name), dest); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// ArchiveFiles 将证书文件移动到所在目录下的 .archive/<时间戳>/，不存在的文件会被忽略
+func ArchiveFiles(paths ...string) (string, error) {
+	if len(paths) == 0 {
+		return "", nil
+	}
+
+	dest := filepath.Join(filepath.Dir(paths[0]), ArchiveDirName, time.Now().Format("20060102150405"))
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return "", err
+	}
+
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.Rename(path, filepath.Join(dest, filepath.Base(path))); err != nil {
+			return dest, err
+		}
+	}
+	return dest, nil
+}
@@ -0,0 +1,60 @@
+package cert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"certctl/internal/hook"
+)
+
+// MetaFileName 证书元数据文件名，与证书保存在同一目录
+const MetaFileName = "certctl.json"
+
+// Meta 证书元数据，记录申请时的参数，续期时沿用
+type Meta struct {
+	Name    string   `json:"name"`           // 证书名称（目录名）
+	Domains []string `json:"domains"`        // 证书包含的全部域名
+	CA      string   `json:"ca"`             // CA 名称
+	CAURL   string   `json:"caUrl"`          // CA 目录地址
+	Email   string   `json:"email"`          // ACME 账户邮箱
+	KeyType string   `json:"keyType"`        // 私钥类型，如 rsa2048、ec256
+	Dual    bool     `json:"dual,omitempty"` // 同时签发 ECDSA 和 RSA 证书
+
+	DNS       string `json:"dns,omitempty"`       // 自动 DNS 验证提供商，为空表示手动验证
+	DNSConfig string `json:"dnsConfig,omitempty"` // 使用的已保存 DNS 配置名称，为空时从环境变量读取凭证
+
+	Hooks hook.Hooks `json:"hooks"` // 证书钩子，在全局钩子之后执行
+}
+
+// SaveMeta 保存证书元数据
+func SaveMeta(outputDir, name string, meta *Meta) error {
+	domainDir := filepath.Join(outputDir, name)
+	if err := os.MkdirAll(domainDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(domainDir, MetaFileName), data, 0600)
+}
+
+// LoadMeta 加载证书元数据，文件不存在时返回 nil
+func LoadMeta(outputDir, name string) (*Meta, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, name, MetaFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
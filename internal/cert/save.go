@@ -5,18 +5,39 @@ import (
 	"encoding/pem"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
+// 双证书模式下的文件变体
+const (
+	VariantECC = "ecc"
+	VariantRSA = "rsa"
+)
+
+// Paths 返回证书和私钥的文件路径，variant 为空时使用 <domain>.pem / <domain>.key
+func Paths(outputDir, domain, variant string) (certPath, keyPath string) {
+	base := domain
+	if variant != "" {
+		base = domain + "." + variant
+	}
+	domainDir := filepath.Join(outputDir, domain)
+	return filepath.Join(domainDir, base+".pem"), filepath.Join(domainDir, base+".key")
+}
+
 // Save 保存证书到文件
 func Save(outputDir, domain string, certPEM, keyPEM []byte) (certPath, keyPath string, err error) {
+	return SaveVariant(outputDir, domain, "", certPEM, keyPEM)
+}
+
+// SaveVariant 保存指定变体的证书，如 example.com.ecc.pem 与 example.com.rsa.pem 并存
+func SaveVariant(outputDir, domain, variant string, certPEM, keyPEM []byte) (certPath, keyPath string, err error) {
 	domainDir := filepath.Join(outputDir, domain)
 	if err = os.MkdirAll(domainDir, 0755); err != nil {
 		return
 	}
 
-	certPath = filepath.Join(domainDir, domain+".pem")
-	keyPath = filepath.Join(domainDir, domain+".key")
+	certPath, keyPath = Paths(outputDir, domain, variant)
 
 	if err = os.WriteFile(certPath, certPEM, 0644); err != nil {
 		return
@@ -34,6 +55,7 @@ type Certificate struct {
 	CertPath string
 	KeyPath  string
 	Domain   string
+	Variant  string // 双证书模式下为 ecc 或 rsa，否则为空
 	NotAfter time.Time
 	DaysLeft int
 }
@@ -54,7 +76,8 @@ func ListCertificates(certsDir string) ([]Certificate, error) {
 	}
 
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		// 跳过文件和 .archive 等隐藏目录
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
 			continue
 		}
 
@@ -85,29 +108,42 @@ func ListCertificates(certsDir string) ([]Certificate, error) {
 			}
 		}
 
-		// 如果没找到证书，跳过
-		if certPath == "" {
-			continue
+		if certPath != "" {
+			if c, err := newCertificate(domain, "", certPath, keyPath); err == nil {
+				certs = append(certs, c)
+			}
 		}
 
-		// 解析证书获取有效期
-		notAfter, err := ParseCertExpiry(certPath)
-		if err != nil {
-			continue
+		// 双证书模式：example.com.ecc.pem 与 example.com.rsa.pem
+		for _, variant := range []string{VariantECC, VariantRSA} {
+			variantCert, variantKey := Paths(certsDir, domain, variant)
+			if _, err := os.Stat(variantCert); err != nil {
+				continue
+			}
+			if c, err := newCertificate(domain, variant, variantCert, variantKey); err == nil {
+				certs = append(certs, c)
+			}
 		}
+	}
 
-		daysLeft := int(time.Until(notAfter).Hours() / 24)
+	return certs, nil
+}
 
-		certs = append(certs, Certificate{
-			CertPath: certPath,
-			KeyPath:  keyPath,
-			Domain:   domain,
-			NotAfter: notAfter,
-			DaysLeft: daysLeft,
-		})
+func newCertificate(domain, variant, certPath, keyPath string) (Certificate, error) {
+	// 解析证书获取有效期
+	notAfter, err := ParseCertExpiry(certPath)
+	if err != nil {
+		return Certificate{}, err
 	}
 
-	return certs, nil
+	return Certificate{
+		CertPath: certPath,
+		KeyPath:  keyPath,
+		Domain:   domain,
+		Variant:  variant,
+		NotAfter: notAfter,
+		DaysLeft: int(time.Until(notAfter).Hours() / 24),
+	}, nil
 }
 
 // ParseCertExpiry 解析证书有效期
@@ -4,14 +4,26 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+
+	"certctl/internal/hook"
 )
 
 // DNSConfig DNS 提供商配置（支持命名的多个配置）
 type DNSConfig struct {
 	Name            string `json:"name"`            // 配置名称，如 "阿里云-公司账号"
-	Provider        string `json:"provider"`        // 提供商类型：aliyun, tencent, cloudflare
-	AccessKeyID     string `json:"accessKeyId"`
-	AccessKeySecret string `json:"accessKeySecret"`
+	Provider        string `json:"provider"`        // 提供商类型：aliyun, tencentcloud, cloudflare
+	AccessKeyID     string `json:"accessKeyId,omitempty"`     // 旧版凭证字段，仅用于读取旧配置
+	AccessKeySecret string `json:"accessKeySecret,omitempty"` // 旧版凭证字段，仅用于读取旧配置
+
+	Credentials map[string]string `json:"credentials,omitempty"` // 凭证，键由 DNS 提供商注册信息定义
+}
+
+// CAConfig 自定义 ACME CA 配置（如 ZeroSSL、内部 step-ca）
+type CAConfig struct {
+	Name         string `json:"name"`         // CA 名称，如 "step-ca"
+	DirectoryURL string `json:"directoryUrl"` // ACME 目录地址
+	EABKeyID     string `json:"eabKeyId,omitempty"`
+	EABHMACKey   string `json:"eabHmacKey,omitempty"`
 }
 
 // AIConfig AI 增强模式配置
@@ -21,13 +33,26 @@ type AIConfig struct {
 	Model   string `json:"model"`   // 模型，默认 glm-4.7
 }
 
+// PropagationConfig DNS 传播检查配置，时长使用 Go 时长格式，如 "5m"、"10s"
+type PropagationConfig struct {
+	Resolvers  []string `json:"resolvers,omitempty"`  // 递归解析器，如 10.0.0.53:53，默认使用公共解析器
+	Timeout    string   `json:"timeout,omitempty"`    // 等待记录生效的超时时间，默认 5m
+	Interval   string   `json:"interval,omitempty"`   // 首次检查间隔，之后指数退避，默认 5s
+	GraceDelay string   `json:"graceDelay,omitempty"` // 记录生效后、通知 CA 验证前的等待时间
+	Recursive  bool     `json:"recursive,omitempty"`  // 权威服务器生效后再检查递归解析器
+}
+
 // Config 应用配置
 type Config struct {
 	Language string      `json:"language"`
 	CertsDir string      `json:"certsDir"`
 	Verbose  bool        `json:"verbose"` // 详细模式
 	DNS      []DNSConfig `json:"dns"`     // 改为数组，支持多个配置
+	CA       []CAConfig  `json:"ca"`      // 自定义 CA 列表
 	AI       AIConfig    `json:"ai"`      // AI 增强模式
+	Hooks    hook.Hooks  `json:"hooks"`   // 全局钩子，对所有证书生效
+
+	Propagation PropagationConfig `json:"propagation"` // DNS 传播检查
 }
 
 var (
@@ -61,6 +86,7 @@ func Load() *Config {
 		Language: "zh",
 		CertsDir: defaultCertsDir,
 		DNS:      []DNSConfig{},
+		CA:       []CAConfig{},
 	}
 
 	data, err := os.ReadFile(configFile)
@@ -72,9 +98,18 @@ func Load() *Config {
 	if current.DNS == nil {
 		current.DNS = []DNSConfig{}
 	}
+	if current.CA == nil {
+		current.CA = []CAConfig{}
+	}
 	return current
 }
 
+// Reload 丢弃缓存，重新从磁盘加载配置
+func Reload() *Config {
+	current = nil
+	return Load()
+}
+
 // Save 保存配置
 func Save() error {
 	// 确保目录存在
@@ -149,15 +184,14 @@ func GetDNSConfigByName(name string) (DNSConfig, bool) {
 }
 
 // AddDNSConfig 添加 DNS 配置
-func AddDNSConfig(name, provider, accessKeyID, accessKeySecret string) {
+func AddDNSConfig(name, provider string, credentials map[string]string) {
 	cfg := Get()
 	// 如果已存在同名配置，先删除
 	DeleteDNSConfig(name)
 	cfg.DNS = append(cfg.DNS, DNSConfig{
-		Name:            name,
-		Provider:        provider,
-		AccessKeyID:     accessKeyID,
-		AccessKeySecret: accessKeySecret,
+		Name:        name,
+		Provider:    provider,
+		Credentials: credentials,
 	})
 	Save()
 }
@@ -185,6 +219,48 @@ func HasProviderConfigs(provider string) bool {
 	return len(GetDNSConfigsByProvider(provider)) > 0
 }
 
+// GetCAConfigs 获取所有自定义 CA 配置
+func GetCAConfigs() []CAConfig {
+	return Get().CA
+}
+
+// GetCAConfigByName 根据名称获取 CA 配置
+func GetCAConfigByName(name string) (CAConfig, bool) {
+	for _, ca := range Get().CA {
+		if ca.Name == name {
+			return ca, true
+		}
+	}
+	return CAConfig{}, false
+}
+
+// AddCAConfig 添加自定义 CA 配置
+func AddCAConfig(name, directoryURL, eabKeyID, eabHMACKey string) {
+	cfg := Get()
+	// 如果已存在同名配置，先删除
+	DeleteCAConfig(name)
+	cfg.CA = append(cfg.CA, CAConfig{
+		Name:         name,
+		DirectoryURL: directoryURL,
+		EABKeyID:     eabKeyID,
+		EABHMACKey:   eabHMACKey,
+	})
+	Save()
+}
+
+// DeleteCAConfig 根据名称删除 CA 配置
+func DeleteCAConfig(name string) {
+	cfg := Get()
+	newCA := []CAConfig{}
+	for _, ca := range cfg.CA {
+		if ca.Name != name {
+			newCA = append(newCA, ca)
+		}
+	}
+	cfg.CA = newCA
+	Save()
+}
+
 // GetAIConfig 获取 AI 配置
 func GetAIConfig() AIConfig {
 	cfg := Get()
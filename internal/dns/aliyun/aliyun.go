@@ -1,6 +1,7 @@
 package aliyun
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 )
 
 // DNSClient 阿里云 DNS 客户端
+// SDK 不支持 context，每次请求前检查 ctx，已取消时不再发起请求
 type DNSClient struct {
 	client *alidns.Client
 }
@@ -30,7 +32,11 @@ func NewDNSClient(accessKey, accessSecret, region string) (*DNSClient, error) {
 }
 
 // AddTXTRecord 添加 TXT 记录
-func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
+func (c *DNSClient) AddTXTRecord(ctx context.Context, domain, rr, value string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	request := alidns.CreateAddDomainRecordRequest()
 	request.Scheme = "https"
 	request.DomainName = domain
@@ -43,7 +49,7 @@ func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
 	if err != nil {
 		// 如果记录已存在，尝试更新
 		if strings.Contains(err.Error(), "DomainRecordDuplicate") {
-			return c.UpdateTXTRecord(domain, rr, value)
+			return c.UpdateTXTRecord(ctx, domain, rr, value)
 		}
 		return fmt.Errorf(i18n.T("error.dns_add"), err)
 	}
@@ -52,16 +58,19 @@ func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
 }
 
 // UpdateTXTRecord 更新 TXT 记录
-func (c *DNSClient) UpdateTXTRecord(domain, rr, value string) error {
+func (c *DNSClient) UpdateTXTRecord(ctx context.Context, domain, rr, value string) error {
 	// 先查询记录 ID
-	recordID, err := c.getRecordID(domain, rr, "TXT")
+	recordID, err := c.getRecordID(ctx, domain, rr, "TXT")
 	if err != nil {
 		return err
 	}
 
 	if recordID == "" {
 		// 记录不存在，添加新记录
-		return c.AddTXTRecord(domain, rr, value)
+		return c.AddTXTRecord(ctx, domain, rr, value)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	// 更新记录
@@ -80,31 +89,48 @@ func (c *DNSClient) UpdateTXTRecord(domain, rr, value string) error {
 	return nil
 }
 
-// DeleteTXTRecord 删除 TXT 记录
-func (c *DNSClient) DeleteTXTRecord(domain, rr string) error {
-	recordID, err := c.getRecordID(domain, rr, "TXT")
-	if err != nil {
+// DeleteTXTRecord 删除 TXT 记录，value 为空时删除该主机记录下的全部 TXT 记录
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, domain, rr, value string) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	if recordID == "" {
-		return nil // 记录不存在，无需删除
-	}
-
-	request := alidns.CreateDeleteDomainRecordRequest()
+	request := alidns.CreateDescribeDomainRecordsRequest()
 	request.Scheme = "https"
-	request.RecordId = recordID
+	request.DomainName = domain
+	request.RRKeyWord = rr
+	request.TypeKeyWord = "TXT"
 
-	_, err = c.client.DeleteDomainRecord(request)
+	response, err := c.client.DescribeDomainRecords(request)
 	if err != nil {
-		return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		return fmt.Errorf(i18n.T("error.dns_query"), err)
+	}
+
+	for _, record := range response.DomainRecords.Record {
+		if record.RR != rr || record.Type != "TXT" || (value != "" && record.Value != value) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		request := alidns.CreateDeleteDomainRecordRequest()
+		request.Scheme = "https"
+		request.RecordId = record.RecordId
+		if _, err := c.client.DeleteDomainRecord(request); err != nil {
+			return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		}
 	}
 
 	return nil
 }
 
 // getRecordID 获取记录 ID
-func (c *DNSClient) getRecordID(domain, rr, recordType string) (string, error) {
+func (c *DNSClient) getRecordID(ctx context.Context, domain, rr, recordType string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	request := alidns.CreateDescribeDomainRecordsRequest()
 	request.Scheme = "https"
 	request.DomainName = domain
@@ -1,6 +1,7 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
@@ -11,81 +12,180 @@ import (
 	"github.com/miekg/dns"
 )
 
+// defaultResolvers 未配置解析器时使用的公共递归解析器
 var defaultResolvers = []string{
 	"8.8.8.8:53",
 	"1.1.1.1:53",
 	"223.5.5.5:53",
 }
 
-// CheckTXTRecord 检查 TXT 记录是否存在
-func CheckTXTRecord(fqdn, expectedValue string) (bool, error) {
-	if !strings.HasSuffix(fqdn, ".") {
-		fqdn = fqdn + "."
-	}
+// 传播检查默认值
+const (
+	DefaultCheckTimeout  = 5 * time.Minute
+	DefaultCheckInterval = 5 * time.Second
+	maxCheckInterval     = time.Minute
+)
 
-	for _, resolver := range defaultResolvers {
-		values, err := queryTXT(fqdn, resolver)
-		if err != nil {
-			continue
-		}
+// CheckOptions 传播检查选项，零值字段使用默认值
+type CheckOptions struct {
+	Resolvers  []string      // 递归解析器，host:port
+	Timeout    time.Duration // 等待记录生效的超时时间
+	Interval   time.Duration // 首次检查间隔，之后每次翻倍，最长 1 分钟
+	GraceDelay time.Duration // 记录生效后、通知 CA 验证前的等待时间
+	Recursive  bool          // 权威服务器全部生效后，再确认递归解析器也能查到
+}
 
-		for _, v := range values {
-			if v == expectedValue {
-				return true, nil
-			}
-		}
-	}
+var checkOptions CheckOptions
 
-	return false, nil
+// SetCheckOptions 设置传播检查选项，手动验证和 lego 自动验证都会使用
+func SetCheckOptions(opts CheckOptions) {
+	checkOptions = opts
 }
 
-func queryTXT(fqdn, resolver string) ([]string, error) {
-	c := new(dns.Client)
-	c.Timeout = 5 * time.Second
+// GetCheckOptions 返回补全默认值后的传播检查选项
+func GetCheckOptions() CheckOptions {
+	opts := checkOptions
+	if len(opts.Resolvers) == 0 {
+		opts.Resolvers = defaultResolvers
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultCheckTimeout
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultCheckInterval
+	}
+	return opts
+}
+
+// Resolvers 返回当前使用的递归解析器
+func Resolvers() []string {
+	return GetCheckOptions().Resolvers
+}
 
-	m := new(dns.Msg)
-	m.SetQuestion(fqdn, dns.TypeTXT)
-	m.RecursionDesired = true
+// CheckTXTRecord 检查 TXT 记录是否已生效
+// 直接查询 zone 的所有权威服务器，可以访问的全部返回该值才算生效，避免递归解析器缓存否定应答；
+// 无法获取或部分无法访问权威服务器时还要求递归解析器查到该值
+func CheckTXTRecord(ctx context.Context, fqdn, expectedValue string) (bool, error) {
+	report := CheckPropagation(ctx, fqdn, expectedValue, false)
+	if report.Propagated {
+		return true, nil
+	}
+	return false, report.firstError()
+}
 
-	r, _, err := c.Exchange(m, resolver)
+// zoneNameservers 返回记录所在 zone 的权威服务器地址
+func zoneNameservers(ctx context.Context, fqdn string) ([]string, error) {
+	zone, err := FindZone(ctx, fqdn)
 	if err != nil {
 		return nil, err
 	}
+	return AuthoritativeNameservers(ctx, zone)
+}
 
-	if r.Rcode != dns.RcodeSuccess {
-		return nil, fmt.Errorf(i18n.T("error.dns_query_fail"), dns.RcodeToString[r.Rcode])
-	}
+// AuthoritativeNameservers 通过递归解析器查询 zone 的 NS 记录，返回 host:53 形式的权威服务器地址
+func AuthoritativeNameservers(ctx context.Context, zone string) ([]string, error) {
+	var lastErr error
+	for _, resolver := range Resolvers() {
+		r, err := query(ctx, dns.Fqdn(zone), dns.TypeNS, resolver, true)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if r.Rcode != dns.RcodeSuccess {
+			lastErr = fmt.Errorf(i18n.T("error.dns_query_fail"), dns.RcodeToString[r.Rcode])
+			continue
+		}
 
-	var values []string
-	for _, ans := range r.Answer {
-		if txt, ok := ans.(*dns.TXT); ok {
-			values = append(values, strings.Join(txt.Txt, ""))
+		var nameservers []string
+		for _, ans := range r.Answer {
+			if ns, ok := ans.(*dns.NS); ok {
+				nameservers = append(nameservers, net.JoinHostPort(strings.TrimSuffix(ns.Ns, "."), "53"))
+			}
+		}
+		if len(nameservers) > 0 {
+			return nameservers, nil
 		}
 	}
-
-	return values, nil
+	return nil, lastErr
 }
 
-// WaitForRecord 等待 DNS 记录生效
-func WaitForRecord(fqdn, expectedValue string, timeout time.Duration, onCheck func(attempt int)) error {
-	deadline := time.Now().Add(timeout)
-	attempt := 0
+// WaitForRecord 等待 DNS 记录生效，检查间隔从 Interval 开始指数退避，直到 Timeout
+// 超时返回 *PropagationError，其中带有各服务器的查询结果；ctx 取消时立即返回 ctx.Err()
+func WaitForRecord(ctx context.Context, fqdn, expectedValue string, onCheck func(attempt int)) error {
+	opts := GetCheckOptions()
+	deadline := time.Now().Add(opts.Timeout)
+	interval := opts.Interval
 
-	for time.Now().Before(deadline) {
-		attempt++
+	for attempt := 1; ; attempt++ {
 		if onCheck != nil {
 			onCheck(attempt)
 		}
 
-		found, err := CheckTXTRecord(fqdn, expectedValue)
-		if err == nil && found {
+		report := CheckPropagation(ctx, fqdn, expectedValue, false)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if report.Propagated {
+			notifyReport(report)
 			return nil
 		}
 
-		time.Sleep(10 * time.Second)
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		if interval > remaining {
+			interval = remaining
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+
+		if interval *= 2; interval > maxCheckInterval {
+			interval = maxCheckInterval
+		}
 	}
 
-	return fmt.Errorf(i18n.T("error.dns_timeout"))
+	// 超时后做一次包含递归解析器的完整检查，便于定位问题
+	report := CheckPropagation(ctx, fqdn, expectedValue, true)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	notifyReport(report)
+	if report.Propagated {
+		return nil
+	}
+	return &PropagationError{Report: report}
+}
+
+func notifyReport(report *Report) {
+	if reportHandler != nil {
+		reportHandler(report)
+	}
+}
+
+// WaitForPropagation 等待记录生效后再等待 GraceDelay，给 CA 侧的解析器留出时间
+func WaitForPropagation(ctx context.Context, fqdn, expectedValue string) error {
+	if err := WaitForRecord(ctx, fqdn, expectedValue, nil); err != nil {
+		return err
+	}
+	if grace := GetCheckOptions().GraceDelay; grace > 0 {
+		return sleep(ctx, grace)
+	}
+	return nil
+}
+
+// sleep 等待 d，ctx 取消时提前返回 ctx.Err()
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // GetLocalIP 获取本机出口 IP
@@ -0,0 +1,217 @@
+package cloudflare
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+)
+
+// DefaultBaseURL Cloudflare API 地址
+const DefaultBaseURL = "https://api.cloudflare.com/client/v4"
+
+// 记录已存在的错误码，重复添加时忽略
+const (
+	codeRecordExists    = 81057
+	codeIdenticalRecord = 81058
+)
+
+// DNSClient Cloudflare DNS 客户端，使用 API Token 认证
+// Token 只需 Zone:Read 和 DNS:Edit 权限，可限定到具体的 Zone
+type DNSClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	zoneIDs    map[string]string // 域名 → Zone ID 缓存
+}
+
+// NewDNSClient 创建 Cloudflare DNS 客户端，baseURL 为空时使用官方地址
+func NewDNSClient(token, baseURL string) (*DNSClient, error) {
+	if token == "" {
+		return nil, fmt.Errorf(i18n.T("error.cloudflare_create"), "API Token 不能为空")
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &DNSClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		zoneIDs:    map[string]string{},
+	}, nil
+}
+
+// apiError Cloudflare 返回的错误
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiResponse Cloudflare API 通用响应
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Errors  []apiError      `json:"errors"`
+	Result  json.RawMessage `json:"result"`
+}
+
+type zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type dnsRecord struct {
+	ID      string `json:"id,omitempty"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl,omitempty"`
+}
+
+// do 发送请求并解析 result，API 返回失败时 errs 非空
+func (c *DNSClient) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) ([]apiError, error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if !apiResp.Success {
+		if len(apiResp.Errors) == 0 {
+			return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		return apiResp.Errors, nil
+	}
+	if result != nil && len(apiResp.Result) > 0 {
+		return nil, json.Unmarshal(apiResp.Result, result)
+	}
+	return nil, nil
+}
+
+// formatErrors 将 API 错误列表格式化为一个 error
+func formatErrors(errs []apiError) error {
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = fmt.Sprintf("%d: %s", e.Code, e.Message)
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
+
+// ZoneID 查询域名所在 Zone 的 ID
+func (c *DNSClient) ZoneID(ctx context.Context, domain string) (string, error) {
+	if id, ok := c.zoneIDs[domain]; ok {
+		return id, nil
+	}
+
+	var zones []zone
+	apiErrs, err := c.do(ctx, http.MethodGet, "/zones", url.Values{"name": {domain}}, nil, &zones)
+	if err == nil && len(apiErrs) > 0 {
+		err = formatErrors(apiErrs)
+	}
+	if err != nil {
+		return "", fmt.Errorf(i18n.T("error.dns_query"), err)
+	}
+	if len(zones) == 0 {
+		return "", fmt.Errorf(i18n.T("error.cloudflare_zone"), domain)
+	}
+
+	c.zoneIDs[domain] = zones[0].ID
+	return zones[0].ID, nil
+}
+
+// AddTXTRecord 添加 TXT 记录，相同记录已存在时视为成功
+func (c *DNSClient) AddTXTRecord(ctx context.Context, domain, rr, value string) error {
+	zoneID, err := c.ZoneID(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	record := dnsRecord{Type: "TXT", Name: recordName(domain, rr), Content: value, TTL: 120}
+	apiErrs, err := c.do(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", nil, record, nil)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dns_add"), err)
+	}
+	for _, e := range apiErrs {
+		if e.Code != codeRecordExists && e.Code != codeIdenticalRecord {
+			return fmt.Errorf(i18n.T("error.dns_add"), formatErrors(apiErrs))
+		}
+	}
+	return nil
+}
+
+// DeleteTXTRecord 删除 TXT 记录，value 为空时删除该主机记录下的全部 TXT 记录
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, domain, rr, value string) error {
+	zoneID, err := c.ZoneID(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	var records []dnsRecord
+	query := url.Values{"type": {"TXT"}, "name": {recordName(domain, rr)}}
+	apiErrs, err := c.do(ctx, http.MethodGet, "/zones/"+zoneID+"/dns_records", query, nil, &records)
+	if err == nil && len(apiErrs) > 0 {
+		err = formatErrors(apiErrs)
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dns_query"), err)
+	}
+
+	for _, r := range records {
+		if value != "" && strings.Trim(r.Content, `"`) != value {
+			continue
+		}
+		apiErrs, err := c.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+r.ID, nil, nil, nil)
+		if err == nil && len(apiErrs) > 0 {
+			err = formatErrors(apiErrs)
+		}
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		}
+	}
+	return nil
+}
+
+// recordName 返回主机记录的完整名称，@ 表示 zone 顶点（验证记录通过 CNAME 委托到 zone 顶点时）
+func recordName(domain, rr string) string {
+	if rr == "" || rr == "@" {
+		return domain
+	}
+	return rr + "." + domain
+}
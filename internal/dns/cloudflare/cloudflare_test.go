@@ -0,0 +1,220 @@
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testToken = "test-token"
+
+// fakeAPI 模拟 Cloudflare API 的 zone 查询和 DNS 记录增删
+type fakeAPI struct {
+	t *testing.T
+
+	mu        sync.Mutex
+	zones     map[string]string // 域名 → Zone ID
+	records   []dnsRecord
+	nextID    int
+	zoneCalls int
+	postErr   *apiError // 设置后 POST 返回该错误
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *DNSClient) {
+	t.Helper()
+	f := &fakeAPI{t: t, zones: map[string]string{"example.com": "zone1"}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	c, err := NewDNSClient(testToken, server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, c
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if got := r.Header.Get("Authorization"); got != "Bearer "+testToken {
+		f.reply(w, http.StatusForbidden, nil, &apiError{Code: 10000, Message: "Authentication error"})
+		return
+	}
+
+	const records = "/zones/zone1/dns_records"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/zones":
+		f.zoneCalls++
+		var zones []zone
+		if id, ok := f.zones[r.URL.Query().Get("name")]; ok {
+			zones = append(zones, zone{ID: id, Name: r.URL.Query().Get("name")})
+		}
+		f.reply(w, http.StatusOK, zones, nil)
+
+	case r.Method == http.MethodPost && r.URL.Path == records:
+		var rec dnsRecord
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			f.t.Errorf("decode record: %v", err)
+		}
+		if f.postErr != nil {
+			f.reply(w, http.StatusBadRequest, nil, f.postErr)
+			return
+		}
+		for _, existing := range f.records {
+			if existing.Name == rec.Name && existing.Content == rec.Content {
+				f.reply(w, http.StatusBadRequest, nil, &apiError{Code: codeIdenticalRecord, Message: "An identical record already exists."})
+				return
+			}
+		}
+		f.nextID++
+		rec.ID = fmt.Sprintf("rec%d", f.nextID)
+		f.records = append(f.records, rec)
+		f.reply(w, http.StatusOK, rec, nil)
+
+	case r.Method == http.MethodGet && r.URL.Path == records:
+		var matched []dnsRecord
+		for _, rec := range f.records {
+			if rec.Type == r.URL.Query().Get("type") && rec.Name == r.URL.Query().Get("name") {
+				matched = append(matched, rec)
+			}
+		}
+		f.reply(w, http.StatusOK, matched, nil)
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, records+"/"):
+		id := strings.TrimPrefix(r.URL.Path, records+"/")
+		for i, rec := range f.records {
+			if rec.ID == id {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				f.reply(w, http.StatusOK, map[string]string{"id": id}, nil)
+				return
+			}
+		}
+		f.reply(w, http.StatusNotFound, nil, &apiError{Code: 81044, Message: "Record does not exist."})
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeAPI) reply(w http.ResponseWriter, status int, result interface{}, apiErr *apiError) {
+	resp := map[string]interface{}{"success": apiErr == nil, "errors": []apiError{}, "result": result}
+	if apiErr != nil {
+		resp["errors"] = []apiError{*apiErr}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeAPI) contents(name string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var values []string
+	for _, rec := range f.records {
+		if rec.Name == name {
+			values = append(values, rec.Content)
+		}
+	}
+	return values
+}
+
+func TestAddTXTRecord(t *testing.T) {
+	f, c := newFakeAPI(t)
+	ctx := context.Background()
+
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	// 重复添加返回 81058，视为成功
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "v1"); err != nil {
+		t.Fatalf("identical record: %v", err)
+	}
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "v2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := f.contents("_acme-challenge.example.com"); strings.Join(got, ",") != "v1,v2" {
+		t.Errorf("records = %v, want [v1 v2]", got)
+	}
+	if f.records[0].Type != "TXT" || f.records[0].TTL != 120 {
+		t.Errorf("record = %+v, want TXT with TTL 120", f.records[0])
+	}
+	// Zone ID 只查询一次
+	if f.zoneCalls != 1 {
+		t.Errorf("zone lookups = %d, want 1", f.zoneCalls)
+	}
+}
+
+func TestAddTXTRecordExists(t *testing.T) {
+	f, c := newFakeAPI(t)
+	f.postErr = &apiError{Code: codeRecordExists, Message: "Record already exists."}
+
+	if err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v"); err != nil {
+		t.Fatalf("81057 should be ignored: %v", err)
+	}
+}
+
+func TestAddTXTRecordError(t *testing.T) {
+	f, c := newFakeAPI(t)
+	f.postErr = &apiError{Code: 9109, Message: "Unauthorized to access requested resource"}
+
+	err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v")
+	if err == nil || !strings.Contains(err.Error(), "9109") {
+		t.Fatalf("err = %v, want 9109", err)
+	}
+}
+
+func TestAddTXTRecordApex(t *testing.T) {
+	f, c := newFakeAPI(t)
+
+	if err := c.AddTXTRecord(context.Background(), "example.com", "@", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.contents("example.com"); len(got) != 1 {
+		t.Errorf("apex records = %v, want one record named example.com", f.records)
+	}
+}
+
+func TestDeleteTXTRecord(t *testing.T) {
+	f, c := newFakeAPI(t)
+	ctx := context.Background()
+
+	for _, v := range []string{"wildcard", "root"} {
+		if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Cloudflare 可能返回带引号的内容
+	f.records[1].Content = `"root"`
+
+	// 只删除值匹配的记录，通配符的记录保留
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.contents("_acme-challenge.example.com"); len(got) != 1 || got[0] != "wildcard" {
+		t.Fatalf("records = %v, want [wildcard]", got)
+	}
+
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.contents("_acme-challenge.example.com"); len(got) != 0 {
+		t.Errorf("records = %v, want none", got)
+	}
+}
+
+func TestZoneNotFound(t *testing.T) {
+	_, c := newFakeAPI(t)
+
+	err := c.AddTXTRecord(context.Background(), "example.org", "_acme-challenge", "v")
+	if err == nil || !strings.Contains(err.Error(), "example.org") {
+		t.Fatalf("err = %v, want zone not found", err)
+	}
+}
@@ -0,0 +1,60 @@
+package dns
+
+import (
+	"context"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// maxCNAMEHops CNAME 链的最大跟随次数，防止循环
+const maxCNAMEHops = 10
+
+// ChallengeFQDN 返回域名的 DNS-01 验证记录名，如 _acme-challenge.example.com
+// 通配符与根域名共用同一记录
+func ChallengeFQDN(domainName string) string {
+	domainName = strings.TrimSuffix(strings.TrimPrefix(domainName, "*."), ".")
+	return "_acme-challenge." + domainName
+}
+
+// DelegationTarget 返回将验证记录委托到 zone 时使用的 CNAME 目标，如 example.com.acme.example.net
+func DelegationTarget(domainName, zone string) string {
+	domainName = strings.TrimSuffix(strings.TrimPrefix(domainName, "*."), ".")
+	return domainName + "." + strings.Trim(zone, ".")
+}
+
+// ResolveCNAME 跟随 fqdn 上的 CNAME 链，返回最终记录名（不带末尾的点）
+// 没有 CNAME 或查询失败时返回原记录名
+func ResolveCNAME(ctx context.Context, fqdn string) string {
+	name := dns.Fqdn(fqdn)
+	seen := map[string]bool{name: true}
+
+	for i := 0; i < maxCNAMEHops; i++ {
+		target, ok := lookupCNAME(ctx, name)
+		if !ok || seen[target] {
+			break
+		}
+		seen[target] = true
+		name = target
+	}
+
+	return strings.TrimSuffix(name, ".")
+}
+
+// lookupCNAME 查询 name 的 CNAME 记录，任一解析器返回结果即可
+func lookupCNAME(ctx context.Context, name string) (string, bool) {
+	for _, resolver := range Resolvers() {
+		r, err := query(ctx, name, dns.TypeCNAME, resolver, true)
+		if err != nil || r.Rcode != dns.RcodeSuccess {
+			continue
+		}
+
+		for _, ans := range r.Answer {
+			if cname, ok := ans.(*dns.CNAME); ok && strings.EqualFold(cname.Hdr.Name, name) {
+				return strings.ToLower(cname.Target), true
+			}
+		}
+		return "", false
+	}
+	return "", false
+}
@@ -0,0 +1,162 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+)
+
+// DefaultTimeout 脚本默认超时时间
+const DefaultTimeout = 60 * time.Second
+
+// maxStderrLines 错误信息中保留的 stderr 行数
+const maxStderrLines = 20
+
+// DNSClient 调用用户脚本管理 TXT 记录，参数约定与 lego exec 提供商一致：
+//
+//	<script> present <fqdn> <value>
+//	<script> cleanup <fqdn> <value>
+//
+// fqdn 以点结尾，如 _acme-challenge.example.com.
+type DNSClient struct {
+	path    string
+	timeout time.Duration
+	env     []string
+}
+
+// NewDNSClient 创建脚本客户端
+// timeout 支持 30s、2m 等时长格式或秒数，为空时使用默认值
+// env 为逗号分隔的 KEY=VALUE，在继承当前环境变量的基础上传给脚本
+func NewDNSClient(path, timeout, env string) (*DNSClient, error) {
+	if path == "" {
+		return nil, fmt.Errorf(i18n.T("error.exec_create"), "脚本路径不能为空")
+	}
+	if _, err := osexec.LookPath(path); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.exec_create"), err)
+	}
+
+	d, err := parseTimeout(timeout)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.exec_create"), err)
+	}
+
+	vars, err := parseEnv(env)
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("error.exec_create"), err)
+	}
+
+	return &DNSClient{path: path, timeout: d, env: vars}, nil
+}
+
+func parseTimeout(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultTimeout, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("无效的超时时间: %s", s)
+	}
+	return d, nil
+}
+
+func parseEnv(s string) ([]string, error) {
+	var vars []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if !strings.Contains(item, "=") || strings.HasPrefix(item, "=") {
+			return nil, fmt.Errorf("无效的环境变量: %s，格式应为 KEY=VALUE", item)
+		}
+		vars = append(vars, item)
+	}
+	return vars, nil
+}
+
+// AddTXTRecord 执行 present
+func (c *DNSClient) AddTXTRecord(ctx context.Context, zone, rr, value string) error {
+	return c.run(ctx, "present", fqdn(zone, rr), value)
+}
+
+// DeleteTXTRecord 执行 cleanup
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, zone, rr, value string) error {
+	return c.run(ctx, "cleanup", fqdn(zone, rr), value)
+}
+
+func fqdn(zone, rr string) string {
+	if rr == "" || rr == "@" {
+		return zone + "."
+	}
+	return rr + "." + zone + "."
+}
+
+// run 执行脚本，超时或 ctx 取消时结束脚本及其子进程
+// stderr 写入临时文件而不是管道，脚本留下的后台进程不会让 run 一直等待输出结束
+func (c *DNSClient) run(parent context.Context, action, fqdn, value string) error {
+	ctx, cancel := context.WithTimeout(parent, c.timeout)
+	defer cancel()
+
+	stderr, err := os.CreateTemp("", "certctl-exec-*")
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.exec_fail"), err)
+	}
+	defer os.Remove(stderr.Name())
+	defer stderr.Close()
+
+	cmd := osexec.Command(c.path, action, fqdn, value)
+	cmd.Env = append(os.Environ(), c.env...)
+	cmd.Stderr = stderr
+	setProcessGroup(cmd)
+
+	if err = cmd.Start(); err == nil {
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			killProcessGroup(cmd.Process)
+			err = <-done
+		}
+	}
+	if parent.Err() != nil {
+		return parent.Err()
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf(i18n.T("error.exec_timeout"), c.timeout)
+	}
+	if err == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s %s %s: %v", c.path, action, fqdn, err)
+	output, _ := os.ReadFile(stderr.Name())
+	if lines := tail(string(output), maxStderrLines); len(lines) > 0 {
+		msg += "\n" + strings.Join(lines, "\n")
+	}
+	return fmt.Errorf(i18n.T("error.exec_fail"), msg)
+}
+
+// tail 返回输出的最后 n 个非空行
+func tail(output string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimRight(line, "\r \t"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,166 @@
+package exec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeScript 在临时目录中创建可执行的 shell 脚本
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on Windows")
+	}
+	path := filepath.Join(t.TempDir(), "dns.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestArguments(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "calls")
+	script := writeScript(t, `echo "$1 $2 $3" >> "$OUT"`+"\n")
+	c, err := NewDNSClient(script, "", "OUT="+out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge.www", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge.www", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddTXTRecord(ctx, "acme.example.net", "@", "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "present _acme-challenge.www.example.com. token-value\n" +
+		"cleanup _acme-challenge.www.example.com. token-value\n" +
+		"present acme.example.net. v\n"
+	if string(data) != want {
+		t.Errorf("calls:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "env")
+	script := writeScript(t, `echo "$INHERITED|$DNS_API_KEY|$DNS_API_URL" > "$OUT"`+"\n")
+	t.Setenv("INHERITED", "from-certctl")
+
+	// 值中可以包含等号，前后空白和空项被忽略
+	c, err := NewDNSClient(script, "", " DNS_API_KEY=k=1 ,,DNS_API_URL=https://dns.example.com,OUT="+out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "from-certctl|k=1|https://dns.example.com\n"; string(data) != want {
+		t.Errorf("env = %q, want %q", data, want)
+	}
+}
+
+func TestParseEnv(t *testing.T) {
+	for _, env := range []string{"NOVALUE", "=value", "A=1,B"} {
+		if _, err := parseEnv(env); err == nil {
+			t.Errorf("parseEnv(%q) should fail", env)
+		}
+	}
+	if vars, err := parseEnv(""); err != nil || len(vars) != 0 {
+		t.Errorf("parseEnv(\"\") = %v, %v", vars, err)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"":    DefaultTimeout,
+		"90":  90 * time.Second,
+		"2m":  2 * time.Minute,
+		"30s": 30 * time.Second,
+	} {
+		if got, err := parseTimeout(in); err != nil || got != want {
+			t.Errorf("parseTimeout(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"0", "-1s", "soon"} {
+		if _, err := parseTimeout(in); err == nil {
+			t.Errorf("parseTimeout(%q) should fail", in)
+		}
+	}
+}
+
+func TestStderrTail(t *testing.T) {
+	script := writeScript(t, `i=1
+while [ $i -le 30 ]; do echo "line $i" >&2; i=$((i+1)); done
+exit 3
+`)
+	c, err := NewDNSClient(script, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v")
+	if err == nil {
+		t.Fatal("expected error for non-zero exit")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "present _acme-challenge.example.com.") || !strings.Contains(msg, "exit status 3") {
+		t.Errorf("error does not name the command and status:\n%s", msg)
+	}
+	// 只保留最后 maxStderrLines 行
+	if !strings.Contains(msg, "line 30") || !strings.Contains(msg, "line 11\n") || strings.Contains(msg, "line 10\n") {
+		t.Errorf("error does not contain the last %d stderr lines:\n%s", maxStderrLines, msg)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	script := writeScript(t, "sleep 10\n")
+	c, err := NewDNSClient(script, "200ms", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	err = c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v")
+	if err == nil || !strings.Contains(err.Error(), "200ms") {
+		t.Fatalf("err = %v, want timeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("script was not killed on timeout, took %s", elapsed)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	script := writeScript(t, "sleep 10\n")
+	c, err := NewDNSClient(script, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "v"); err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("script was not killed on cancel, took %s", elapsed)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+	"syscall"
+)
+
+// setProcessGroup 让脚本在独立的进程组中运行，超时时连同它启动的子进程一起结束
+func setProcessGroup(cmd *osexec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessGroup 结束脚本所在的整个进程组
+func killProcessGroup(p *os.Process) {
+	if err := syscall.Kill(-p.Pid, syscall.SIGKILL); err != nil {
+		p.Kill()
+	}
+}
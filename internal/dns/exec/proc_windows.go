@@ -0,0 +1,14 @@
+package exec
+
+import (
+	"os"
+	osexec "os/exec"
+)
+
+// setProcessGroup Windows 下没有进程组，只结束脚本进程本身
+func setProcessGroup(cmd *osexec.Cmd) {}
+
+// killProcessGroup 结束脚本进程
+func killProcessGroup(p *os.Process) {
+	p.Kill()
+}
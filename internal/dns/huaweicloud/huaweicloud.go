@@ -0,0 +1,257 @@
+package huaweicloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+)
+
+// DefaultRegion 默认区域，公网域名在任意区域的终端节点均可管理
+const DefaultRegion = "cn-north-4"
+
+// DNSClient 华为云 DNS 客户端，使用 AK/SK 签名认证
+type DNSClient struct {
+	endpoint   string
+	signer     *signer
+	projectID  string
+	httpClient *http.Client
+	zoneIDs    map[string]string // 域名 → Zone ID 缓存
+}
+
+// NewDNSClient 创建华为云 DNS 客户端
+// region 为空时使用默认区域，endpoint 为空时按区域生成，projectID 为空时使用 AK 所属账号的默认项目
+func NewDNSClient(accessKey, secretKey, region, projectID, endpoint string) (*DNSClient, error) {
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf(i18n.T("error.huaweicloud_create"), "AK/SK 不能为空")
+	}
+	if region == "" {
+		region = DefaultRegion
+	}
+	if endpoint == "" {
+		endpoint = "https://dns." + region + ".myhuaweicloud.com"
+	}
+
+	return &DNSClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		signer:     &signer{key: accessKey, secret: secretKey},
+		projectID:  projectID,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		zoneIDs:    map[string]string{},
+	}, nil
+}
+
+type zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type recordset struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	TTL     int      `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+// apiError 华为云返回的错误，DNS 服务和 API 网关的字段名不同
+type apiError struct {
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	ErrorCode    string `json:"error_code"`
+	ErrorMessage string `json:"error_msg"`
+}
+
+func (e apiError) String() string {
+	if e.Code != "" {
+		return e.Code + ": " + e.Message
+	}
+	return e.ErrorCode + ": " + e.ErrorMessage
+}
+
+// do 发送签名请求并解析响应
+func (c *DNSClient) do(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	u := c.endpoint + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.projectID != "" {
+		req.Header.Set("X-Project-Id", c.projectID)
+	}
+	c.signer.sign(req, data, time.Now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= 300 {
+		var apiErr apiError
+		if json.Unmarshal(respData, &apiErr) == nil && (apiErr.Code != "" || apiErr.ErrorCode != "") {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiErr)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respData)))
+	}
+	if result != nil && len(respData) > 0 {
+		return json.Unmarshal(respData, result)
+	}
+	return nil
+}
+
+// ZoneID 查询公网域名的 Zone ID
+func (c *DNSClient) ZoneID(ctx context.Context, domain string) (string, error) {
+	if id, ok := c.zoneIDs[domain]; ok {
+		return id, nil
+	}
+
+	var resp struct {
+		Zones []zone `json:"zones"`
+	}
+	query := url.Values{"type": {"public"}, "name": {domain + "."}}
+	if err := c.do(ctx, http.MethodGet, "/v2/zones", query, nil, &resp); err != nil {
+		return "", fmt.Errorf(i18n.T("error.dns_query"), err)
+	}
+
+	// name 参数为模糊匹配，需要精确比对
+	for _, z := range resp.Zones {
+		if strings.EqualFold(strings.TrimSuffix(z.Name, "."), domain) {
+			c.zoneIDs[domain] = z.ID
+			return z.ID, nil
+		}
+	}
+	return "", fmt.Errorf(i18n.T("error.huaweicloud_zone"), domain)
+}
+
+// findRecordset 查询主机记录的 TXT 记录集，不存在时返回 nil
+func (c *DNSClient) findRecordset(ctx context.Context, zoneID, name string) (*recordset, error) {
+	var resp struct {
+		Recordsets []recordset `json:"recordsets"`
+	}
+	query := url.Values{"type": {"TXT"}, "name": {name}}
+	if err := c.do(ctx, http.MethodGet, "/v2/zones/"+zoneID+"/recordsets", query, nil, &resp); err != nil {
+		return nil, fmt.Errorf(i18n.T("error.dns_query"), err)
+	}
+
+	for i, r := range resp.Recordsets {
+		if strings.EqualFold(r.Name, name) && r.Type == "TXT" {
+			return &resp.Recordsets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// AddTXTRecord 添加 TXT 记录
+// 华为云同名同类型的记录保存在同一个记录集中，已有记录集时追加记录值
+func (c *DNSClient) AddTXTRecord(ctx context.Context, domain, rr, value string) error {
+	zoneID, err := c.ZoneID(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	name := recordName(domain, rr)
+	existing, err := c.findRecordset(ctx, zoneID, name)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		rs := recordset{Name: name, Type: "TXT", TTL: 300, Records: []string{quote(value)}}
+		if err := c.do(ctx, http.MethodPost, "/v2/zones/"+zoneID+"/recordsets", nil, rs, nil); err != nil {
+			return fmt.Errorf(i18n.T("error.dns_add"), err)
+		}
+		return nil
+	}
+
+	for _, r := range existing.Records {
+		if unquote(r) == value {
+			return nil
+		}
+	}
+	records := append(existing.Records, quote(value))
+	if err := c.do(ctx, http.MethodPut, "/v2/zones/"+zoneID+"/recordsets/"+existing.ID, nil, recordset{Records: records}, nil); err != nil {
+		return fmt.Errorf(i18n.T("error.dns_update"), err)
+	}
+	return nil
+}
+
+// DeleteTXTRecord 删除 TXT 记录，value 为空或删除后记录集为空时删除整个记录集
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, domain, rr, value string) error {
+	zoneID, err := c.ZoneID(ctx, domain)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.findRecordset(ctx, zoneID, recordName(domain, rr))
+	if err != nil || existing == nil {
+		return err
+	}
+
+	var remaining []string
+	if value != "" {
+		for _, r := range existing.Records {
+			if unquote(r) != value {
+				remaining = append(remaining, r)
+			}
+		}
+		if len(remaining) == len(existing.Records) {
+			return nil
+		}
+	}
+
+	if len(remaining) == 0 {
+		err = c.do(ctx, http.MethodDelete, "/v2/zones/"+zoneID+"/recordsets/"+existing.ID, nil, nil, nil)
+	} else {
+		err = c.do(ctx, http.MethodPut, "/v2/zones/"+zoneID+"/recordsets/"+existing.ID, nil, recordset{Records: remaining}, nil)
+	}
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dns_delete"), err)
+	}
+	return nil
+}
+
+// recordName 华为云使用以点结尾的完整记录名
+func recordName(domain, rr string) string {
+	if rr == "" || rr == "@" {
+		return domain + "."
+	}
+	return rr + "." + domain + "."
+}
+
+// quote TXT 记录值需要带双引号
+func quote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	return strings.ReplaceAll(value, `\"`, `"`)
+}
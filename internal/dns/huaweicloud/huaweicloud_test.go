@@ -0,0 +1,291 @@
+package huaweicloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testAK = "test-ak"
+
+// fakeAPI 模拟华为云 DNS 的 zone 查询和记录集增删改
+type fakeAPI struct {
+	t *testing.T
+
+	mu         sync.Mutex
+	recordsets []recordset
+	nextID     int
+	requests   []string // 方法 + 路径，用于检查调用了哪个接口
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *DNSClient) {
+	t.Helper()
+	f := &fakeAPI{t: t}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	c, err := NewDNSClient(testAK, "test-sk", "", "", server.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, c
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if got := r.Header.Get(headerAuthz); !strings.HasPrefix(got, "SDK-HMAC-SHA256 Access="+testAK+",") {
+		f.reply(w, http.StatusUnauthorized, apiError{ErrorCode: "APIGW.0301", ErrorMessage: "Incorrect IAM authentication information"})
+		return
+	}
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	const recordsets = "/v2/zones/zone1/recordsets"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v2/zones":
+		// name 参数为模糊匹配，同时返回子域名的 zone
+		var zones []zone
+		if name := r.URL.Query().Get("name"); name == "example.com." {
+			zones = []zone{{ID: "zone2", Name: "sub.example.com."}, {ID: "zone1", Name: "example.com."}}
+		}
+		f.reply(w, http.StatusOK, map[string]interface{}{"zones": zones})
+
+	case r.Method == http.MethodGet && r.URL.Path == recordsets:
+		var matched []recordset
+		for _, rs := range f.recordsets {
+			if rs.Type == r.URL.Query().Get("type") && rs.Name == r.URL.Query().Get("name") {
+				matched = append(matched, rs)
+			}
+		}
+		f.reply(w, http.StatusOK, map[string]interface{}{"recordsets": matched})
+
+	case r.Method == http.MethodPost && r.URL.Path == recordsets:
+		var rs recordset
+		if err := json.NewDecoder(r.Body).Decode(&rs); err != nil {
+			f.t.Errorf("decode recordset: %v", err)
+		}
+		f.nextID++
+		rs.ID = fmt.Sprintf("rs%d", f.nextID)
+		f.recordsets = append(f.recordsets, rs)
+		f.reply(w, http.StatusAccepted, rs)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, recordsets+"/"):
+		var update recordset
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			f.t.Errorf("decode recordset: %v", err)
+		}
+		if rs := f.find(strings.TrimPrefix(r.URL.Path, recordsets+"/")); rs != nil {
+			rs.Records = update.Records
+			f.reply(w, http.StatusAccepted, rs)
+			return
+		}
+		f.reply(w, http.StatusNotFound, apiError{Code: "DNS.0312", Message: "Record set does not exist."})
+
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, recordsets+"/"):
+		id := strings.TrimPrefix(r.URL.Path, recordsets+"/")
+		for i, rs := range f.recordsets {
+			if rs.ID == id {
+				f.recordsets = append(f.recordsets[:i], f.recordsets[i+1:]...)
+				f.reply(w, http.StatusAccepted, rs)
+				return
+			}
+		}
+		f.reply(w, http.StatusNotFound, apiError{Code: "DNS.0312", Message: "Record set does not exist."})
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeAPI) find(id string) *recordset {
+	for i := range f.recordsets {
+		if f.recordsets[i].ID == id {
+			return &f.recordsets[i]
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) reply(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// add 预置一个记录集
+func (f *fakeAPI) add(name string, records ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	f.recordsets = append(f.recordsets, recordset{ID: fmt.Sprintf("rs%d", f.nextID), Name: name, Type: "TXT", TTL: 300, Records: records})
+}
+
+func (f *fakeAPI) records(name string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, rs := range f.recordsets {
+		if rs.Name == name {
+			return rs.Records
+		}
+	}
+	return nil
+}
+
+// calls 返回指定方法的请求次数
+func (f *fakeAPI) calls(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, r := range f.requests {
+		if strings.HasPrefix(r, method+" ") {
+			n++
+		}
+	}
+	return n
+}
+
+const challengeName = "_acme-challenge.example.com."
+
+func TestAddTXTRecordCreate(t *testing.T) {
+	f, c := newFakeAPI(t)
+
+	if err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v1"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.records(challengeName); len(got) != 1 || got[0] != `"v1"` {
+		t.Fatalf("records = %q, want [\"v1\"] with quotes", got)
+	}
+	if rs := f.recordsets[0]; rs.Type != "TXT" || rs.TTL != 300 {
+		t.Errorf("recordset = %+v, want TXT with TTL 300", rs)
+	}
+}
+
+func TestAddTXTRecordAppend(t *testing.T) {
+	f, c := newFakeAPI(t)
+	f.add(challengeName, `"wildcard"`)
+	ctx := context.Background()
+
+	// 同名记录集已存在时追加记录值
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.records(challengeName); strings.Join(got, ",") != `"wildcard","root"` {
+		t.Fatalf("records = %q", got)
+	}
+
+	// 已有的值不重复添加
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.calls(http.MethodPut); n != 1 {
+		t.Errorf("PUT requests = %d, want 1", n)
+	}
+	if n := f.calls(http.MethodPost); n != 0 {
+		t.Errorf("POST requests = %d, want 0", n)
+	}
+}
+
+func TestDeleteTXTRecordPartial(t *testing.T) {
+	f, c := newFakeAPI(t)
+	f.add(challengeName, `"wildcard"`, `"root"`)
+
+	// 只删除匹配的值，通配符的记录保留
+	if err := c.DeleteTXTRecord(context.Background(), "example.com", "_acme-challenge", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.records(challengeName); len(got) != 1 || got[0] != `"wildcard"` {
+		t.Fatalf("records = %q, want [\"wildcard\"]", got)
+	}
+	if n := f.calls(http.MethodDelete); n != 0 {
+		t.Errorf("DELETE requests = %d, want 0", n)
+	}
+}
+
+func TestDeleteTXTRecordWhole(t *testing.T) {
+	f, c := newFakeAPI(t)
+	f.add(challengeName, `"root"`)
+	ctx := context.Background()
+
+	// 删除最后一个值时删除整个记录集
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", "root"); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.recordsets) != 0 {
+		t.Fatalf("recordsets = %+v, want none", f.recordsets)
+	}
+
+	// 不指定值时删除整个记录集
+	f.add(challengeName, `"a"`, `"b"`)
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.recordsets) != 0 {
+		t.Fatalf("recordsets = %+v, want none", f.recordsets)
+	}
+	if n := f.calls(http.MethodDelete); n != 2 {
+		t.Errorf("DELETE requests = %d, want 2", n)
+	}
+
+	// 记录集不存在时视为已删除
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", "root"); err != nil {
+		t.Errorf("missing recordset: %v", err)
+	}
+}
+
+func TestQuotedValues(t *testing.T) {
+	f, c := newFakeAPI(t)
+	ctx := context.Background()
+
+	const value = `a"b`
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", value); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.records(challengeName); len(got) != 1 || got[0] != `"a\"b"` {
+		t.Fatalf("records = %q, want escaped quotes", got)
+	}
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", value); err != nil {
+		t.Fatal(err)
+	}
+	if len(f.recordsets) != 0 {
+		t.Errorf("recordsets = %+v, want none", f.recordsets)
+	}
+
+	for in, want := range map[string]string{
+		`"token"`:   "token",
+		`token`:     "token",
+		`"a\"b"`:    `a"b`,
+		`""`:        "",
+		`"`:         `"`,
+		`"tok\"en"`: `tok"en`,
+	} {
+		if got := unquote(in); got != want {
+			t.Errorf("unquote(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestZoneExactMatch(t *testing.T) {
+	f, c := newFakeAPI(t)
+
+	id, err := c.ZoneID(context.Background(), "example.com")
+	if err != nil || id != "zone1" {
+		t.Fatalf("ZoneID = %q, %v, want zone1", id, err)
+	}
+	if _, err := c.ZoneID(context.Background(), "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.calls(http.MethodGet); n != 1 {
+		t.Errorf("zone lookups = %d, want 1 (cached)", n)
+	}
+
+	if _, err := c.ZoneID(context.Background(), "example.org"); err == nil || !strings.Contains(err.Error(), "example.org") {
+		t.Errorf("err = %v, want zone not found", err)
+	}
+}
@@ -0,0 +1,158 @@
+package dns
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"certctl/internal/i18n"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// Field 提供商的一个凭证字段
+type Field struct {
+	Key      string   // 配置中的键名
+	Label    string   // 表单中显示的名称
+	Flag     string   // 命令行参数名
+	Env      []string // 环境变量，按顺序查找
+	Secret   bool     // 是否为密钥，输入和显示时隐藏
+	Optional bool     // 是否可以留空
+}
+
+// Credentials 凭证，键为 Field.Key
+type Credentials map[string]string
+
+// Provider 自动 DNS 验证提供商
+// CLI 参数、交互表单、配置保存和 lego challenge.Provider 都由注册信息生成
+type Provider struct {
+	Name    string // 提供商标识，用于 --dns 参数和配置
+	Title   string // 显示名称的 i18n key
+	HelpURL string // 获取凭证的地址
+	Fields  []Field
+	New     func(c Credentials) (challenge.Provider, error)
+}
+
+var (
+	providers = map[string]Provider{}
+	order     []string // 注册顺序，决定菜单中的顺序
+)
+
+// Register 注册提供商，名称重复时 panic
+func Register(p Provider) {
+	if _, ok := providers[p.Name]; ok {
+		panic("dns: provider registered twice: " + p.Name)
+	}
+	providers[p.Name] = p
+	order = append(order, p.Name)
+}
+
+// Get 按名称获取提供商
+func Get(name string) (Provider, bool) {
+	p, ok := providers[name]
+	return p, ok
+}
+
+// Providers 按注册顺序返回所有提供商
+func Providers() []Provider {
+	list := make([]Provider, 0, len(order))
+	for _, name := range order {
+		list = append(list, providers[name])
+	}
+	return list
+}
+
+// Names 返回所有提供商名称，按字母排序
+func Names() []string {
+	names := append([]string(nil), order...)
+	sort.Strings(names)
+	return names
+}
+
+// DisplayName 当前语言下的显示名称
+func (p Provider) DisplayName() string {
+	return i18n.T(p.Title)
+}
+
+// FromEnv 从环境变量读取凭证，未设置的字段不出现在结果中
+func (p Provider) FromEnv() Credentials {
+	c := Credentials{}
+	for _, f := range p.Fields {
+		for _, env := range f.Env {
+			if v := os.Getenv(env); v != "" {
+				c[f.Key] = v
+				break
+			}
+		}
+	}
+	return c
+}
+
+// Missing 返回缺少的必填字段
+func (p Provider) Missing(c Credentials) []Field {
+	var missing []Field
+	for _, f := range p.Fields {
+		if !f.Optional && c[f.Key] == "" {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
+
+// Validate 检查必填字段
+func (p Provider) Validate(c Credentials) error {
+	missing := p.Missing(c)
+	if len(missing) == 0 {
+		return nil
+	}
+	names := make([]string, len(missing))
+	for i, f := range missing {
+		names[i] = f.Label
+	}
+	return fmt.Errorf(i18n.T("error.dns_credentials"), p.DisplayName(), strings.Join(names, ", "))
+}
+
+// Mask 返回用于列表显示的凭证摘要：优先显示第一个非密钥字段
+func (p Provider) Mask(c Credentials) string {
+	for _, f := range p.Fields {
+		if !f.Secret && !f.Optional && c[f.Key] != "" {
+			return mask(c[f.Key])
+		}
+	}
+	for _, f := range p.Fields {
+		if c[f.Key] != "" {
+			return mask(c[f.Key])
+		}
+	}
+	return ""
+}
+
+// LegacyCredentials 将旧版配置的 AccessKeyID/AccessKeySecret 转换为凭证
+// 旧版两字段提供商按顺序对应，单字段提供商的值保存在 AccessKeySecret 中
+func (p Provider) LegacyCredentials(id, secret string) Credentials {
+	var required []Field
+	for _, f := range p.Fields {
+		if !f.Optional {
+			required = append(required, f)
+		}
+	}
+
+	c := Credentials{}
+	switch len(required) {
+	case 1:
+		c[required[0].Key] = secret
+	case 2:
+		c[required[0].Key] = id
+		c[required[1].Key] = secret
+	}
+	return c
+}
+
+// mask 遮盖敏感值，只保留首尾各 4 位
+func mask(s string) string {
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:4] + "****" + s[len(s)-4:]
+}
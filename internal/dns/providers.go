@@ -0,0 +1,140 @@
+package dns
+
+import (
+	"certctl/internal/dns/aliyun"
+	"certctl/internal/dns/cloudflare"
+	"certctl/internal/dns/exec"
+	"certctl/internal/dns/huaweicloud"
+	"certctl/internal/dns/rfc2136"
+	"certctl/internal/dns/tencentcloud"
+	"certctl/internal/dns/webhook"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// 内置提供商，注册顺序即菜单顺序
+func init() {
+	Register(Provider{
+		Name:    "aliyun",
+		Title:   "dns.aliyun",
+		HelpURL: "https://ram.console.aliyun.com/manage/ak",
+		Fields: []Field{
+			{Key: "accessKeyId", Label: "AccessKey ID", Flag: "ali-key", Env: []string{"ALICLOUD_ACCESS_KEY"}},
+			{Key: "accessKeySecret", Label: "AccessKey Secret", Flag: "ali-secret", Env: []string{"ALICLOUD_SECRET_KEY"}, Secret: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := aliyun.NewDNSClient(c["accessKeyId"], c["accessKeySecret"], "")
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:    "tencentcloud",
+		Title:   "dns.tencentcloud",
+		HelpURL: "https://console.cloud.tencent.com/cam/capi",
+		Fields: []Field{
+			{Key: "secretId", Label: "SecretId", Flag: "tencent-id", Env: []string{"TENCENTCLOUD_SECRET_ID"}},
+			{Key: "secretKey", Label: "SecretKey", Flag: "tencent-secret", Env: []string{"TENCENTCLOUD_SECRET_KEY"}, Secret: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := tencentcloud.NewDNSClient(c["secretId"], c["secretKey"], "")
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:    "huaweicloud",
+		Title:   "dns.huaweicloud",
+		HelpURL: "https://console.huaweicloud.com/iam/#/mine/accessKey",
+		Fields: []Field{
+			{Key: "accessKeyId", Label: "Access Key ID", Flag: "huawei-ak", Env: []string{"HUAWEICLOUD_ACCESS_KEY_ID"}},
+			{Key: "secretAccessKey", Label: "Secret Access Key", Flag: "huawei-sk", Env: []string{"HUAWEICLOUD_SECRET_ACCESS_KEY"}, Secret: true},
+			{Key: "region", Label: "Region", Flag: "huawei-region", Env: []string{"HUAWEICLOUD_REGION"}, Optional: true},
+			{Key: "projectId", Label: "Project ID", Flag: "huawei-project-id", Env: []string{"HUAWEICLOUD_PROJECT_ID"}, Optional: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := huaweicloud.NewDNSClient(c["accessKeyId"], c["secretAccessKey"], c["region"], c["projectId"], "")
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:    "cloudflare",
+		Title:   "dns.cloudflare",
+		HelpURL: "https://dash.cloudflare.com/profile/api-tokens",
+		Fields: []Field{
+			{Key: "apiToken", Label: "API Token", Flag: "cf-token", Env: []string{"CLOUDFLARE_DNS_API_TOKEN", "CF_DNS_API_TOKEN"}, Secret: true},
+			{Key: "baseUrl", Label: "API Base URL", Flag: "cf-base-url", Env: []string{"CLOUDFLARE_BASE_URL"}, Optional: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := cloudflare.NewDNSClient(c["apiToken"], c["baseUrl"])
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:  "rfc2136",
+		Title: "dns.rfc2136",
+		Fields: []Field{
+			{Key: "nameserver", Label: "Nameserver", Flag: "rfc2136-nameserver", Env: []string{"RFC2136_NAMESERVER"}},
+			{Key: "tsigKey", Label: "TSIG Key", Flag: "rfc2136-tsig-key", Env: []string{"RFC2136_TSIG_KEY"}, Optional: true},
+			{Key: "tsigAlgorithm", Label: "TSIG Algorithm", Flag: "rfc2136-tsig-algorithm", Env: []string{"RFC2136_TSIG_ALGORITHM"}, Optional: true},
+			{Key: "tsigSecret", Label: "TSIG Secret", Flag: "rfc2136-tsig-secret", Env: []string{"RFC2136_TSIG_SECRET"}, Secret: true, Optional: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := rfc2136.NewDNSClient(c["nameserver"], c["tsigKey"], c["tsigAlgorithm"], c["tsigSecret"])
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:  "exec",
+		Title: "dns.exec",
+		Fields: []Field{
+			{Key: "path", Label: "Script", Flag: "exec-path", Env: []string{"EXEC_PATH"}},
+			{Key: "timeout", Label: "Timeout", Flag: "exec-timeout", Env: []string{"EXEC_TIMEOUT"}, Optional: true},
+			// 脚本通常从环境变量读取 DNS 服务的 API 密钥，整个 KEY=VALUE 列表按密钥处理，输入时不回显
+			// 配置列表和 --verbose 只显示脚本路径
+			{Key: "env", Label: "Env (KEY=VALUE,...)", Flag: "exec-env", Env: []string{"EXEC_ENV"}, Secret: true, Optional: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := exec.NewDNSClient(c["path"], c["timeout"], c["env"])
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+
+	Register(Provider{
+		Name:  "webhook",
+		Title: "dns.webhook",
+		Fields: []Field{
+			{Key: "url", Label: "URL", Flag: "webhook-url", Env: []string{"WEBHOOK_URL"}},
+			{Key: "token", Label: "Bearer Token", Flag: "webhook-token", Env: []string{"WEBHOOK_TOKEN"}, Secret: true, Optional: true},
+			{Key: "hmacSecret", Label: "HMAC Secret", Flag: "webhook-hmac-secret", Env: []string{"WEBHOOK_HMAC_SECRET"}, Secret: true, Optional: true},
+		},
+		New: func(c Credentials) (challenge.Provider, error) {
+			client, err := webhook.NewDNSClient(c["url"], c["token"], c["hmacSecret"])
+			if err != nil {
+				return nil, err
+			}
+			return NewTXTProvider(client), nil
+		},
+	})
+}
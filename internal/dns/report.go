@@ -0,0 +1,205 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+
+	"github.com/miekg/dns"
+)
+
+// Status 单个服务器的查询结果
+type Status string
+
+const (
+	StatusOK       Status = "ok"       // 返回了期望的值
+	StatusMismatch Status = "mismatch" // 有 TXT 记录，但值不匹配
+	StatusEmpty    Status = "empty"    // 名称存在但没有 TXT 记录
+	StatusNXDomain Status = "nxdomain" // 名称不存在
+	StatusError    Status = "error"    // 网络错误或异常应答
+)
+
+// Label 返回结果的显示文本
+func (s Status) Label() string {
+	return i18n.T("propagation." + string(s))
+}
+
+// ServerResult 单个权威服务器或递归解析器的查询结果
+type ServerResult struct {
+	Server        string        // host:port
+	Authoritative bool          // 是否为权威服务器
+	Status        Status        // 查询结果
+	Values        []string      // 返回的 TXT 值
+	Latency       time.Duration // 查询耗时
+	Err           error         // StatusError 时的错误
+}
+
+// Report 一次传播检查的结果
+type Report struct {
+	FQDN       string // 实际检查的记录名（已跟随 CNAME）
+	Expected   string // 期望的 TXT 值
+	Propagated bool   // 是否已生效
+	Servers    []ServerResult
+}
+
+// Kind 返回服务器类型的显示文本
+func (r ServerResult) Kind() string {
+	if r.Authoritative {
+		return i18n.T("propagation.authoritative")
+	}
+	return i18n.T("propagation.recursive")
+}
+
+// Detail 返回值或错误信息
+func (r ServerResult) Detail() string {
+	if r.Status == StatusError && r.Err != nil {
+		return r.Err.Error()
+	}
+	return strings.Join(r.Values, ", ")
+}
+
+// LatencyText 返回以毫秒表示的查询耗时
+func (r ServerResult) LatencyText() string {
+	return fmt.Sprintf("%dms", r.Latency.Milliseconds())
+}
+
+// String 以文本形式输出检查结果，每个服务器一行，用于日志和 AI 诊断
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s TXT %q\n", strings.TrimSuffix(r.FQDN, "."), r.Expected)
+	for _, s := range r.Servers {
+		fmt.Fprintf(&b, "%s (%s): %s", s.Server, s.Kind(), s.Status.Label())
+		if detail := s.Detail(); detail != "" {
+			fmt.Fprintf(&b, " [%s]", detail)
+		}
+		fmt.Fprintf(&b, " %s\n", s.LatencyText())
+	}
+	if unreachable := r.Unreachable(); len(unreachable) > 0 {
+		fmt.Fprintf(&b, i18n.T("propagation.unreachable")+"\n", strings.Join(unreachable, ", "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Unreachable 返回无法访问的权威服务器
+func (r *Report) Unreachable() []string {
+	var servers []string
+	for _, s := range r.Servers {
+		if s.Authoritative && s.Status == StatusError {
+			servers = append(servers, s.Server)
+		}
+	}
+	return servers
+}
+
+// firstError 返回第一个权威服务器的查询错误
+func (r *Report) firstError() error {
+	for _, s := range r.Servers {
+		if s.Authoritative && s.Err != nil {
+			return s.Err
+		}
+	}
+	return nil
+}
+
+// PropagationError 等待记录生效超时，附带最后一次检查结果
+type PropagationError struct {
+	Report *Report
+}
+
+func (e *PropagationError) Error() string {
+	return i18n.T("error.dns_timeout")
+}
+
+var reportHandler func(*Report)
+
+// SetReportHandler 设置检查结果回调，每次等待结束（生效或超时）时调用
+func SetReportHandler(handler func(*Report)) {
+	reportHandler = handler
+}
+
+// CheckPropagation 检查 TXT 记录在各服务器上的状态
+// 可以访问的权威服务器全部返回期望值才算生效；无法获取权威服务器或开启了递归检查时，还要求任一递归解析器返回期望值。
+// 无法访问的权威服务器（如只有 IPv6 地址，或出口屏蔽了 UDP 53 只允许 DoH/DoT）结果未知，不视为未生效，
+// 此时同样要求递归解析器返回期望值；权威服务器全部无法访问时由递归解析器的结果决定。
+// full 为 true 时总是查询递归解析器，用于输出完整的诊断结果
+func CheckPropagation(ctx context.Context, fqdn, expectedValue string, full bool) *Report {
+	fqdn = dns.Fqdn(ResolveCNAME(ctx, fqdn))
+	report := &Report{FQDN: fqdn, Expected: expectedValue, Propagated: true}
+
+	nameservers, err := zoneNameservers(ctx, fqdn)
+	for _, ns := range nameservers {
+		result := lookupTXT(ctx, fqdn, expectedValue, ns, false)
+		report.Servers = append(report.Servers, result)
+		if result.Status != StatusOK && result.Status != StatusError {
+			report.Propagated = false
+		}
+	}
+
+	needRecursive := err != nil || len(nameservers) == 0 || len(report.Unreachable()) > 0 || GetCheckOptions().Recursive
+	if !needRecursive && !full {
+		return report
+	}
+
+	recursiveOK := false
+	for _, resolver := range Resolvers() {
+		result := lookupTXT(ctx, fqdn, expectedValue, resolver, true)
+		report.Servers = append(report.Servers, result)
+		if result.Status == StatusOK {
+			recursiveOK = true
+		}
+	}
+	if needRecursive && !recursiveOK {
+		report.Propagated = false
+	}
+	return report
+}
+
+// lookupTXT 查询单个服务器并分类结果
+func lookupTXT(ctx context.Context, fqdn, expectedValue, server string, recursive bool) ServerResult {
+	result := ServerResult{Server: server, Authoritative: !recursive}
+
+	start := time.Now()
+	r, err := query(ctx, fqdn, dns.TypeTXT, server, recursive)
+	result.Latency = time.Since(start)
+
+	switch {
+	case err != nil:
+		result.Status, result.Err = StatusError, err
+		return result
+	case r.Rcode == dns.RcodeNameError:
+		result.Status = StatusNXDomain
+		return result
+	case r.Rcode != dns.RcodeSuccess:
+		result.Status = StatusError
+		result.Err = fmt.Errorf(i18n.T("error.dns_query_fail"), dns.RcodeToString[r.Rcode])
+		return result
+	}
+
+	for _, ans := range r.Answer {
+		if txt, ok := ans.(*dns.TXT); ok {
+			result.Values = append(result.Values, strings.Join(txt.Txt, ""))
+		}
+	}
+
+	switch {
+	case contains(result.Values, expectedValue):
+		result.Status = StatusOK
+	case len(result.Values) == 0:
+		result.Status = StatusEmpty
+	default:
+		result.Status = StatusMismatch
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
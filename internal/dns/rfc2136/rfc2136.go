@@ -0,0 +1,165 @@
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+
+	"github.com/miekg/dns"
+)
+
+// DefaultAlgorithm 默认 TSIG 算法
+const DefaultAlgorithm = dns.HmacSHA256
+
+// algorithms 支持的 TSIG 算法，键为不带结尾点的小写名称
+var algorithms = map[string]string{
+	"hmac-md5":    dns.HmacMD5,
+	"hmac-sha1":   dns.HmacSHA1,
+	"hmac-sha224": dns.HmacSHA224,
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha384": dns.HmacSHA384,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// DNSClient RFC 2136 动态更新客户端，适用于 BIND、Knot、PowerDNS 等
+type DNSClient struct {
+	nameserver string
+	keyName    string // TSIG 密钥名称（FQDN），为空表示不签名
+	algorithm  string
+	secret     string
+	client     *dns.Client
+}
+
+// NewDNSClient 创建动态更新客户端
+// nameserver 为 host 或 host:port，默认端口 53；keyName 和 secret 需同时提供或同时为空
+func NewDNSClient(nameserver, keyName, algorithm, secret string) (*DNSClient, error) {
+	if nameserver == "" {
+		return nil, fmt.Errorf(i18n.T("error.rfc2136_create"), "nameserver 不能为空")
+	}
+	if _, _, err := net.SplitHostPort(nameserver); err != nil {
+		nameserver = net.JoinHostPort(strings.Trim(nameserver, "[]"), "53")
+	}
+
+	if (keyName == "") != (secret == "") {
+		return nil, fmt.Errorf(i18n.T("error.rfc2136_create"), "TSIG 密钥名称和密钥需同时提供")
+	}
+
+	alg := DefaultAlgorithm
+	if algorithm != "" {
+		var ok bool
+		if alg, ok = algorithms[strings.TrimSuffix(strings.ToLower(algorithm), ".")]; !ok {
+			return nil, fmt.Errorf(i18n.T("error.rfc2136_algorithm"), algorithm)
+		}
+	}
+
+	c := &DNSClient{
+		nameserver: nameserver,
+		algorithm:  alg,
+		secret:     secret,
+		client:     &dns.Client{Net: "udp", Timeout: 10 * time.Second},
+	}
+	if keyName != "" {
+		c.keyName = dns.Fqdn(keyName)
+		c.client.TsigSecret = map[string]string{c.keyName: secret}
+	}
+	return c, nil
+}
+
+// AddTXTRecord 添加 TXT 记录
+func (c *DNSClient) AddTXTRecord(ctx context.Context, zone, rr, value string) error {
+	record, err := c.txtRecord(zone, rr, value)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error.dns_add"), err)
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Insert([]dns.RR{record})
+	if err := c.exchange(ctx, m); err != nil {
+		return fmt.Errorf(i18n.T("error.dns_add"), err)
+	}
+	return nil
+}
+
+// DeleteTXTRecord 删除 TXT 记录，value 为空时删除该主机记录下的全部 TXT 记录
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, zone, rr, value string) error {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+
+	if value == "" {
+		m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{
+			Name:   fqdn(zone, rr),
+			Rrtype: dns.TypeTXT,
+			Class:  dns.ClassANY,
+		}}})
+	} else {
+		record, err := c.txtRecord(zone, rr, value)
+		if err != nil {
+			return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		}
+		m.Remove([]dns.RR{record})
+	}
+
+	if err := c.exchange(ctx, m); err != nil {
+		return fmt.Errorf(i18n.T("error.dns_delete"), err)
+	}
+	return nil
+}
+
+// FindZone 向配置的服务器查询 SOA 确定 fqdn 所在的 zone，内网或 split-horizon zone 在公共解析器上查不到
+// 服务器对其权威的名称在应答或授权段中返回 zone 的 SOA；不是该名称的权威服务器或无法访问时返回空字符串，
+// 由调用方回退到公共解析器查询
+func (c *DNSClient) FindZone(ctx context.Context, fqdn string) (string, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(fqdn), dns.TypeSOA)
+	m.RecursionDesired = false
+
+	r, _, err := c.client.ExchangeContext(ctx, m, c.nameserver)
+	if err != nil {
+		return "", ctx.Err()
+	}
+	if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+		return "", nil
+	}
+	for _, section := range [][]dns.RR{r.Answer, r.Ns} {
+		for _, rr := range section {
+			if soa, ok := rr.(*dns.SOA); ok && dns.IsSubDomain(soa.Hdr.Name, dns.Fqdn(fqdn)) {
+				return strings.TrimSuffix(soa.Hdr.Name, "."), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// txtRecord 构造 TXT 资源记录
+func (c *DNSClient) txtRecord(zone, rr, value string) (dns.RR, error) {
+	return dns.NewRR(fmt.Sprintf("%s 120 IN TXT %q", fqdn(zone, rr), value))
+}
+
+// fqdn 返回主机记录的完整名称，@ 表示 zone 顶点
+func fqdn(zone, rr string) string {
+	if rr == "" || rr == "@" {
+		return dns.Fqdn(zone)
+	}
+	return dns.Fqdn(rr + "." + zone)
+}
+
+// exchange 发送更新请求，配置了 TSIG 时签名
+func (c *DNSClient) exchange(ctx context.Context, m *dns.Msg) error {
+	if c.keyName != "" {
+		m.SetTsig(c.keyName, c.algorithm, 300, time.Now().Unix())
+	}
+
+	r, _, err := c.client.ExchangeContext(ctx, m, c.nameserver)
+	if err != nil {
+		return err
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("%s", dns.RcodeToString[r.Rcode])
+	}
+	return nil
+}
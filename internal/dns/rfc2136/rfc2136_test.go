@@ -0,0 +1,230 @@
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	testKey    = "certctl."
+	testSecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+)
+
+// testServer 进程内的权威服务器，记录收到的更新请求
+type testServer struct {
+	addr string
+
+	mu      sync.Mutex
+	updates []*dns.Msg
+	soa     map[string]string // 查询名称 → 所在 zone
+}
+
+func startServer(t *testing.T, soa map[string]string) *testServer {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := &testServer{addr: pc.LocalAddr().String(), soa: soa}
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn:        pc,
+		TsigSecret:        map[string]string{testKey: testSecret},
+		Handler:           dns.HandlerFunc(ts.serve),
+		NotifyStartedFunc: func() { close(started) },
+		// 默认的 MsgAcceptFunc 以 NOTIMP 拒绝 UPDATE
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go server.ActivateAndServe()
+	t.Cleanup(func() { server.Shutdown() })
+	<-started
+	return ts
+}
+
+func (ts *testServer) serve(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+
+	switch {
+	case r.Opcode == dns.OpcodeUpdate && (r.IsTsig() == nil || w.TsigStatus() != nil):
+		m.Rcode = dns.RcodeNotAuth
+	case r.Opcode == dns.OpcodeUpdate:
+		ts.mu.Lock()
+		ts.updates = append(ts.updates, r.Copy())
+		ts.mu.Unlock()
+	case r.Question[0].Qtype == dns.TypeSOA:
+		zone, ok := ts.soa[r.Question[0].Name]
+		if !ok {
+			m.Rcode = dns.RcodeRefused
+			break
+		}
+		soa := &dns.SOA{
+			Hdr:    dns.RR_Header{Name: zone, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 60},
+			Ns:     "ns1." + zone,
+			Mbox:   "hostmaster." + zone,
+			Serial: 1,
+		}
+		if zone == r.Question[0].Name {
+			m.Answer = []dns.RR{soa}
+		} else {
+			m.Rcode = dns.RcodeNameError
+			m.Ns = []dns.RR{soa}
+		}
+	}
+
+	if t := r.IsTsig(); t != nil && w.TsigStatus() == nil {
+		m.SetTsig(t.Hdr.Name, t.Algorithm, 300, time.Now().Unix())
+	}
+	w.WriteMsg(m)
+}
+
+func (ts *testServer) updateCount() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return len(ts.updates)
+}
+
+func (ts *testServer) lastUpdate(t *testing.T) *dns.Msg {
+	t.Helper()
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if len(ts.updates) == 0 {
+		t.Fatal("no UPDATE received")
+	}
+	return ts.updates[len(ts.updates)-1]
+}
+
+func newTestClient(t *testing.T, addr, secret string) *DNSClient {
+	t.Helper()
+	c, err := NewDNSClient(addr, strings.TrimSuffix(testKey, "."), "hmac-sha256", secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// checkUpdate 检查更新请求的 zone 段和唯一的更新记录
+func checkUpdate(t *testing.T, m *dns.Msg, zone string, class uint16) dns.RR {
+	t.Helper()
+	if len(m.Question) != 1 || m.Question[0].Name != zone || m.Question[0].Qtype != dns.TypeSOA {
+		t.Fatalf("zone section = %v, want %s SOA", m.Question, zone)
+	}
+	if m.IsTsig() == nil {
+		t.Fatal("UPDATE is not TSIG signed")
+	}
+	if len(m.Ns) != 1 {
+		t.Fatalf("update section = %v, want one record", m.Ns)
+	}
+	if got := m.Ns[0].Header().Class; got != class {
+		t.Fatalf("class = %s, want %s", dns.ClassToString[got], dns.ClassToString[class])
+	}
+	return m.Ns[0]
+}
+
+func TestAddTXTRecord(t *testing.T) {
+	ts := startServer(t, nil)
+	c := newTestClient(t, ts.addr, testSecret)
+
+	if err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge.www", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+
+	rr := checkUpdate(t, ts.lastUpdate(t), "example.com.", dns.ClassINET)
+	txt, ok := rr.(*dns.TXT)
+	if !ok {
+		t.Fatalf("record = %v, want TXT", rr)
+	}
+	if txt.Hdr.Name != "_acme-challenge.www.example.com." {
+		t.Errorf("name = %s", txt.Hdr.Name)
+	}
+	if txt.Hdr.Ttl != 120 {
+		t.Errorf("ttl = %d, want 120", txt.Hdr.Ttl)
+	}
+	if len(txt.Txt) != 1 || txt.Txt[0] != "token-value" {
+		t.Errorf("txt = %q", txt.Txt)
+	}
+}
+
+func TestAddTXTRecordApex(t *testing.T) {
+	ts := startServer(t, nil)
+	c := newTestClient(t, ts.addr, testSecret)
+
+	if err := c.AddTXTRecord(context.Background(), "acme.example.net", "@", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if rr := checkUpdate(t, ts.lastUpdate(t), "acme.example.net.", dns.ClassINET); rr.Header().Name != "acme.example.net." {
+		t.Errorf("name = %s, want zone apex", rr.Header().Name)
+	}
+}
+
+func TestDeleteTXTRecord(t *testing.T) {
+	ts := startServer(t, nil)
+	c := newTestClient(t, ts.addr, testSecret)
+
+	// 指定值时只删除该值，与通配符共用的其他值保留
+	if err := c.DeleteTXTRecord(context.Background(), "example.com", "_acme-challenge", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+	rr := checkUpdate(t, ts.lastUpdate(t), "example.com.", dns.ClassNONE)
+	txt, ok := rr.(*dns.TXT)
+	if !ok || txt.Hdr.Name != "_acme-challenge.example.com." || len(txt.Txt) != 1 || txt.Txt[0] != "token-value" {
+		t.Errorf("record = %v", rr)
+	}
+
+	// 不指定值时删除整个 TXT 记录集
+	if err := c.DeleteTXTRecord(context.Background(), "example.com", "_acme-challenge", ""); err != nil {
+		t.Fatal(err)
+	}
+	rr = checkUpdate(t, ts.lastUpdate(t), "example.com.", dns.ClassANY)
+	if rr.Header().Rrtype != dns.TypeTXT || rr.Header().Name != "_acme-challenge.example.com." {
+		t.Errorf("record = %v", rr)
+	}
+}
+
+func TestTSIGFailure(t *testing.T) {
+	ts := startServer(t, nil)
+	c := newTestClient(t, ts.addr, "d3Jvbmctc2VjcmV0")
+
+	err := c.AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v")
+	if err == nil {
+		t.Fatal("expected error with wrong TSIG secret")
+	}
+	if !strings.Contains(err.Error(), "NOTAUTH") {
+		t.Errorf("err = %v, want NOTAUTH", err)
+	}
+	if n := ts.updateCount(); n != 0 {
+		t.Errorf("server accepted %d updates", n)
+	}
+}
+
+func TestFindZone(t *testing.T) {
+	ts := startServer(t, map[string]string{
+		"_acme-challenge.dev.internal.test.": "dev.internal.test.",
+		"dev.internal.test.":                 "dev.internal.test.",
+	})
+	c := newTestClient(t, ts.addr, testSecret)
+
+	for _, name := range []string{"_acme-challenge.dev.internal.test", "dev.internal.test"} {
+		zone, err := c.FindZone(context.Background(), name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if zone != "dev.internal.test" {
+			t.Errorf("FindZone(%s) = %q, want dev.internal.test", name, zone)
+		}
+	}
+
+	// 不是权威服务器时返回空，由调用方回退到公共解析器
+	zone, err := c.FindZone(context.Background(), "_acme-challenge.example.org")
+	if err != nil || zone != "" {
+		t.Errorf("FindZone = %q, %v, want empty", zone, err)
+	}
+}
@@ -1,6 +1,7 @@
 package tencentcloud
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -33,7 +34,7 @@ func NewDNSClient(secretId, secretKey, region string) (*DNSClient, error) {
 }
 
 // AddTXTRecord 添加 TXT 记录
-func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
+func (c *DNSClient) AddTXTRecord(ctx context.Context, domain, rr, value string) error {
 	request := dnspod.NewCreateRecordRequest()
 	request.Domain = common.StringPtr(domain)
 	request.SubDomain = common.StringPtr(rr)
@@ -41,11 +42,11 @@ func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
 	request.RecordLine = common.StringPtr("默认")
 	request.Value = common.StringPtr(value)
 
-	_, err := c.client.CreateRecord(request)
+	_, err := c.client.CreateRecordWithContext(ctx, request)
 	if err != nil {
 		// 如果记录已存在，尝试更新
 		if strings.Contains(err.Error(), "RecordAlreadyExists") || strings.Contains(err.Error(), "记录已存在") {
-			return c.UpdateTXTRecord(domain, rr, value)
+			return c.UpdateTXTRecord(ctx, domain, rr, value)
 		}
 		return fmt.Errorf(i18n.T("error.dns_add"), err)
 	}
@@ -54,16 +55,16 @@ func (c *DNSClient) AddTXTRecord(domain, rr, value string) error {
 }
 
 // UpdateTXTRecord 更新 TXT 记录
-func (c *DNSClient) UpdateTXTRecord(domain, rr, value string) error {
+func (c *DNSClient) UpdateTXTRecord(ctx context.Context, domain, rr, value string) error {
 	// 先查询记录 ID
-	recordID, err := c.getRecordID(domain, rr, "TXT")
+	recordID, err := c.getRecordID(ctx, domain, rr, "TXT")
 	if err != nil {
 		return err
 	}
 
 	if recordID == 0 {
 		// 记录不存在，添加新记录
-		return c.AddTXTRecord(domain, rr, value)
+		return c.AddTXTRecord(ctx, domain, rr, value)
 	}
 
 	// 更新记录
@@ -75,7 +76,7 @@ func (c *DNSClient) UpdateTXTRecord(domain, rr, value string) error {
 	request.RecordLine = common.StringPtr("默认")
 	request.Value = common.StringPtr(value)
 
-	_, err = c.client.ModifyRecord(request)
+	_, err = c.client.ModifyRecordWithContext(ctx, request)
 	if err != nil {
 		return fmt.Errorf(i18n.T("error.dns_update"), err)
 	}
@@ -83,37 +84,46 @@ func (c *DNSClient) UpdateTXTRecord(domain, rr, value string) error {
 	return nil
 }
 
-// DeleteTXTRecord 删除 TXT 记录
-func (c *DNSClient) DeleteTXTRecord(domain, rr string) error {
-	recordID, err := c.getRecordID(domain, rr, "TXT")
-	if err != nil {
-		return err
-	}
+// DeleteTXTRecord 删除 TXT 记录，value 为空时删除该主机记录下的全部 TXT 记录
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, domain, rr, value string) error {
+	request := dnspod.NewDescribeRecordListRequest()
+	request.Domain = common.StringPtr(domain)
+	request.Subdomain = common.StringPtr(rr)
+	request.RecordType = common.StringPtr("TXT")
 
-	if recordID == 0 {
-		return nil // 记录不存在，无需删除
+	response, err := c.client.DescribeRecordListWithContext(ctx, request)
+	if err != nil {
+		// 没有任何记录时接口返回 ResourceNotFound.NoDataOfRecord
+		if strings.Contains(err.Error(), "NoDataOfRecord") {
+			return nil
+		}
+		return fmt.Errorf(i18n.T("error.dns_query"), err)
 	}
 
-	request := dnspod.NewDeleteRecordRequest()
-	request.Domain = common.StringPtr(domain)
-	request.RecordId = common.Uint64Ptr(recordID)
+	for _, record := range response.Response.RecordList {
+		if record.RecordId == nil || (value != "" && record.Value != nil && *record.Value != value) {
+			continue
+		}
 
-	_, err = c.client.DeleteRecord(request)
-	if err != nil {
-		return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		request := dnspod.NewDeleteRecordRequest()
+		request.Domain = common.StringPtr(domain)
+		request.RecordId = record.RecordId
+		if _, err := c.client.DeleteRecordWithContext(ctx, request); err != nil {
+			return fmt.Errorf(i18n.T("error.dns_delete"), err)
+		}
 	}
 
 	return nil
 }
 
 // getRecordID 获取记录 ID
-func (c *DNSClient) getRecordID(domain, rr, recordType string) (uint64, error) {
+func (c *DNSClient) getRecordID(ctx context.Context, domain, rr, recordType string) (uint64, error) {
 	request := dnspod.NewDescribeRecordListRequest()
 	request.Domain = common.StringPtr(domain)
 	request.Subdomain = common.StringPtr(rr)
 	request.RecordType = common.StringPtr(recordType)
 
-	response, err := c.client.DescribeRecordList(request)
+	response, err := c.client.DescribeRecordListWithContext(ctx, request)
 	if err != nil {
 		return 0, fmt.Errorf(i18n.T("error.dns_query"), err)
 	}
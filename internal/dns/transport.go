@@ -0,0 +1,156 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+
+	"github.com/miekg/dns"
+)
+
+// queryTimeout 单次查询超时时间
+const queryTimeout = 5 * time.Second
+
+// 解析器地址格式：
+//
+//	8.8.8.8 / 8.8.8.8:53 / udp://8.8.8.8   普通 DNS（UDP，截断时改用 TCP）
+//	tcp://8.8.8.8:53                       DNS over TCP
+//	tls://dns.google / tls://1.1.1.1:853   DNS over TLS（RFC 7858），默认端口 853
+//	https://dns.google/dns-query           DNS over HTTPS（RFC 8484）
+const (
+	schemeTCP   = "tcp://"
+	schemeTLS   = "tls://"
+	schemeHTTPS = "https://"
+)
+
+var dohClient = &http.Client{Timeout: queryTimeout}
+
+// NormalizeResolver 校验解析器地址并补全默认端口
+func NormalizeResolver(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	invalid := fmt.Errorf(i18n.T("error.dns_resolver"), addr)
+
+	switch {
+	case strings.HasPrefix(addr, schemeHTTPS):
+		u, err := url.Parse(addr)
+		if err != nil || u.Host == "" {
+			return "", invalid
+		}
+		return addr, nil
+	case strings.HasPrefix(addr, schemeTLS):
+		hostport, ok := withPort(strings.TrimPrefix(addr, schemeTLS), "853")
+		if !ok {
+			return "", invalid
+		}
+		return schemeTLS + hostport, nil
+	case strings.HasPrefix(addr, schemeTCP):
+		hostport, ok := withPort(strings.TrimPrefix(addr, schemeTCP), "53")
+		if !ok {
+			return "", invalid
+		}
+		return schemeTCP + hostport, nil
+	}
+
+	hostport, ok := withPort(strings.TrimPrefix(addr, "udp://"), "53")
+	if !ok {
+		return "", invalid
+	}
+	return hostport, nil
+}
+
+// withPort 为 host 补全端口，支持 [IPv6]:port 和裸 IPv6 地址
+func withPort(addr, port string) (string, bool) {
+	if addr == "" || strings.Contains(addr, "/") {
+		return "", false
+	}
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr, true
+	}
+	host := strings.Trim(addr, "[]")
+	if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+		return "", false
+	}
+	return net.JoinHostPort(host, port), true
+}
+
+// IsPlainResolver 是否为普通 DNS 解析器（UDP/TCP 53），lego 只支持这种地址
+func IsPlainResolver(addr string) bool {
+	return !strings.HasPrefix(addr, schemeHTTPS) && !strings.HasPrefix(addr, schemeTLS) && !strings.HasPrefix(addr, schemeTCP)
+}
+
+// query 向 server 发起查询，recursive 为 false 时用于直接查询权威服务器
+// server 可以是普通地址，也可以是 tcp://、tls:// 或 https:// 地址
+func query(ctx context.Context, name string, qtype uint16, server string, recursive bool) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(name, qtype)
+	m.RecursionDesired = recursive
+
+	switch {
+	case strings.HasPrefix(server, schemeHTTPS):
+		return queryHTTPS(ctx, m, server)
+	case strings.HasPrefix(server, schemeTLS):
+		addr := strings.TrimPrefix(server, schemeTLS)
+		host, _, _ := net.SplitHostPort(addr)
+		c := &dns.Client{Net: "tcp-tls", Timeout: queryTimeout, TLSConfig: &tls.Config{ServerName: host}}
+		r, _, err := c.ExchangeContext(ctx, m, addr)
+		return r, err
+	case strings.HasPrefix(server, schemeTCP):
+		c := &dns.Client{Net: "tcp", Timeout: queryTimeout}
+		r, _, err := c.ExchangeContext(ctx, m, strings.TrimPrefix(server, schemeTCP))
+		return r, err
+	}
+
+	c := &dns.Client{Timeout: queryTimeout}
+	r, _, err := c.ExchangeContext(ctx, m, server)
+	if err == nil && r.Truncated {
+		c.Net = "tcp"
+		r, _, err = c.ExchangeContext(ctx, m, server)
+	}
+	return r, err
+}
+
+// queryHTTPS 按 RFC 8484 以 POST application/dns-message 发送查询
+func queryHTTPS(ctx context.Context, m *dns.Msg, endpoint string) (*dns.Msg, error) {
+	// RFC 8484 建议 ID 置 0，便于 HTTP 缓存
+	m.Id = 0
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	resp, err := dohClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	r := new(dns.Msg)
+	if err := r.Unpack(body); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
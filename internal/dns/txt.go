@@ -0,0 +1,126 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"certctl/internal/i18n"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+)
+
+// TXTClient 通过 DNS 服务商 API 管理 TXT 记录
+// zone 为托管的根域名，rr 为相对于 zone 的主机记录，如 _acme-challenge.www
+// ctx 取消时应尽快中止请求并返回错误
+type TXTClient interface {
+	AddTXTRecord(ctx context.Context, zone, rr, value string) error
+	// DeleteTXTRecord 删除值为 value 的记录，通配符与根域名共用同一主机记录，不能全部删除
+	DeleteTXTRecord(ctx context.Context, zone, rr, value string) error
+}
+
+// ZoneFinder 可由 TXTClient 实现，自行确定记录所在的 zone，如向配置的权威服务器查询 SOA，
+// 适用于公共解析器查不到的内网或 split-horizon zone；返回空字符串时按 SOA 公共查询确定
+type ZoneFinder interface {
+	FindZone(ctx context.Context, fqdn string) (string, error)
+}
+
+// ContextProvider 支持取消的 challenge.Provider
+// lego 的 Present/CleanUp 不带 context，ACME 客户端优先调用这两个方法
+type ContextProvider interface {
+	challenge.Provider
+	PresentContext(ctx context.Context, domain, token, keyAuth string) error
+	CleanUpContext(ctx context.Context, domain, token, keyAuth string) error
+}
+
+// ChallengeRecord 计算 DNS-01 验证记录：所在 zone、主机记录和 TXT 值
+// 验证记录名配置了 CNAME 时跟随到最终目标，TXT 记录写在目标所在的 zone
+func ChallengeRecord(ctx context.Context, domainName, keyAuth string) (zone, rr, value string, err error) {
+	_, value = dns01.GetRecord(domainName, keyAuth)
+	zone, rr, err = SplitRecord(ctx, ResolveCNAME(ctx, ChallengeFQDN(domainName)))
+	if err != nil {
+		return "", "", "", err
+	}
+	return zone, rr, value, nil
+}
+
+// SplitRecord 将完整记录名拆分为所在 zone 和相对于 zone 的主机记录，zone 通过 SOA 查询确定
+func SplitRecord(ctx context.Context, fqdn string) (zone, rr string, err error) {
+	fqdn = strings.ToLower(strings.TrimSuffix(fqdn, "."))
+
+	zone, err = FindZone(ctx, fqdn)
+	if err != nil {
+		return "", "", fmt.Errorf(i18n.T("error.domain_parse"), err)
+	}
+	return zone, relativeName(fqdn, zone), nil
+}
+
+// relativeName 返回 fqdn 相对于 zone 的主机记录，zone 顶点为 @
+func relativeName(fqdn, zone string) string {
+	if fqdn == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(fqdn, "."+zone)
+}
+
+// TXTProvider 基于 TXTClient 的 lego challenge.Provider
+type TXTProvider struct {
+	client TXTClient
+}
+
+// NewTXTProvider 创建基于 TXTClient 的验证提供者
+func NewTXTProvider(client TXTClient) *TXTProvider {
+	return &TXTProvider{client: client}
+}
+
+func (p *TXTProvider) Present(domainName, token, keyAuth string) error {
+	return p.PresentContext(context.Background(), domainName, token, keyAuth)
+}
+
+func (p *TXTProvider) CleanUp(domainName, token, keyAuth string) error {
+	return p.CleanUpContext(context.Background(), domainName, token, keyAuth)
+}
+
+// record 计算验证记录，客户端实现了 ZoneFinder 时优先由客户端确定 zone
+func (p *TXTProvider) record(ctx context.Context, domainName, keyAuth string) (zone, rr, value string, err error) {
+	finder, ok := p.client.(ZoneFinder)
+	if !ok {
+		return ChallengeRecord(ctx, domainName, keyAuth)
+	}
+
+	_, value = dns01.GetRecord(domainName, keyAuth)
+	fqdn := strings.ToLower(strings.TrimSuffix(ResolveCNAME(ctx, ChallengeFQDN(domainName)), "."))
+	if zone, err = finder.FindZone(ctx, fqdn); err != nil {
+		return "", "", "", fmt.Errorf(i18n.T("error.domain_parse"), err)
+	}
+	if zone == "" {
+		if zone, rr, err = SplitRecord(ctx, fqdn); err != nil {
+			return "", "", "", err
+		}
+		return zone, rr, value, nil
+	}
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	return zone, relativeName(fqdn, zone), value, nil
+}
+
+// PresentContext 添加验证记录
+func (p *TXTProvider) PresentContext(ctx context.Context, domainName, token, keyAuth string) error {
+	zone, rr, value, err := p.record(ctx, domainName, keyAuth)
+	if err != nil {
+		return err
+	}
+	return p.client.AddTXTRecord(ctx, zone, rr, value)
+}
+
+// CleanUpContext 删除验证记录，无法确定记录位置时返回错误，由调用方提示手动删除
+func (p *TXTProvider) CleanUpContext(ctx context.Context, domainName, token, keyAuth string) error {
+	zone, rr, value, err := p.record(ctx, domainName, keyAuth)
+	if err != nil {
+		return err
+	}
+	return p.client.DeleteTXTRecord(ctx, zone, rr, value)
+}
+
+// 确保实现了接口
+var _ ContextProvider = (*TXTProvider)(nil)
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"certctl/internal/i18n"
+)
+
+// SignatureHeader HMAC 签名请求头，值为 sha256=<请求体 HMAC-SHA256 的十六进制>
+const SignatureHeader = "X-Certctl-Signature"
+
+// maxAttempts 5xx 或网络错误时的最大请求次数
+const maxAttempts = 3
+
+// defaultRetryDelay 首次重试的等待时间，之后按倍数递增
+const defaultRetryDelay = 2 * time.Second
+
+// Request 发送给 Webhook 的请求体
+type Request struct {
+	FQDN   string `json:"fqdn"`   // 完整记录名，以点结尾，如 _acme-challenge.example.com.
+	Value  string `json:"value"`  // TXT 记录值
+	Action string `json:"action"` // present 或 cleanup
+}
+
+// DNSClient 通过 HTTP Webhook 管理 TXT 记录，2xx 响应视为成功
+type DNSClient struct {
+	url        string
+	token      string
+	hmacSecret string
+	httpClient *http.Client
+	retryDelay time.Duration // 首次重试的等待时间
+}
+
+// NewDNSClient 创建 Webhook 客户端，token 和 hmacSecret 均为可选
+func NewDNSClient(endpoint, token, hmacSecret string) (*DNSClient, error) {
+	if endpoint == "" {
+		return nil, fmt.Errorf(i18n.T("error.webhook_create"), "URL 不能为空")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf(i18n.T("error.webhook_create"), "无效的 URL: "+endpoint)
+	}
+
+	return &DNSClient{
+		url:        endpoint,
+		token:      token,
+		hmacSecret: hmacSecret,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retryDelay: defaultRetryDelay,
+	}, nil
+}
+
+// AddTXTRecord 发送 present 请求
+func (c *DNSClient) AddTXTRecord(ctx context.Context, zone, rr, value string) error {
+	if err := c.send(ctx, Request{FQDN: fqdn(zone, rr), Value: value, Action: "present"}); err != nil {
+		return fmt.Errorf(i18n.T("error.dns_add"), err)
+	}
+	return nil
+}
+
+// DeleteTXTRecord 发送 cleanup 请求
+func (c *DNSClient) DeleteTXTRecord(ctx context.Context, zone, rr, value string) error {
+	if err := c.send(ctx, Request{FQDN: fqdn(zone, rr), Value: value, Action: "cleanup"}); err != nil {
+		return fmt.Errorf(i18n.T("error.dns_delete"), err)
+	}
+	return nil
+}
+
+func fqdn(zone, rr string) string {
+	if rr == "" || rr == "@" {
+		return zone + "."
+	}
+	return rr + "." + zone + "."
+}
+
+// Sign 计算请求体的 HMAC-SHA256 签名
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// send 发送请求，5xx 和网络错误按递增间隔重试，ctx 取消时停止重试
+func (c *DNSClient) send(ctx context.Context, r Request) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	delay := c.retryDelay
+	for attempt := 1; ; attempt++ {
+		retry, err := c.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= maxAttempts || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay *= 2
+	}
+}
+
+// post 发送一次请求，返回失败是否可以重试
+func (c *DNSClient) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.hmacSecret != "" {
+		req.Header.Set(SignatureHeader, Sign(c.hmacSecret, body))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("HTTP %d", resp.StatusCode)
+	if msg := strings.TrimSpace(string(data)); msg != "" {
+		err = fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg)
+	}
+	return resp.StatusCode >= 500, err
+}
@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 签名方式是与用户接收端的约定，修改会使已部署的接收端全部校验失败
+const (
+	testSecret     = "webhook-secret"
+	presentBody    = `{"fqdn":"_acme-challenge.example.com.","value":"token-value","action":"present"}`
+	presentSig     = "sha256=a1f739a7227e29f5324f2e8cd223933f2db4b79a711f9564c55811aa9ce65e09"
+	cleanupBody    = `{"fqdn":"_acme-challenge.example.com.","value":"token-value","action":"cleanup"}`
+	cleanupSig     = "sha256=13dc7b8e12861fed0de44246d23fb7784779d6c5ffa4ed74129945b25e1dee1b"
+	testToken      = "webhook-token"
+	wantAuthHeader = "Bearer " + testToken
+)
+
+func TestSign(t *testing.T) {
+	if got := Sign(testSecret, []byte(presentBody)); got != presentSig {
+		t.Errorf("Sign = %s, want %s", got, presentSig)
+	}
+}
+
+// received 接收端收到的一次请求
+type received struct {
+	body          string
+	contentType   string
+	authorization string
+	signature     string
+}
+
+// newReceiver 启动接收端，第 i 次请求返回 statuses[i]，之后返回 200
+func newReceiver(t *testing.T, statuses ...int) (*[]received, string) {
+	t.Helper()
+	var reqs []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		reqs = append(reqs, received{
+			body:          string(body),
+			contentType:   r.Header.Get("Content-Type"),
+			authorization: r.Header.Get("Authorization"),
+			signature:     r.Header.Get(SignatureHeader),
+		})
+		if n := len(reqs); n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return &reqs, server.URL
+}
+
+func TestSignedRequests(t *testing.T) {
+	reqs, url := newReceiver(t)
+	c, err := NewDNSClient(url, testToken, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if err := c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteTXTRecord(ctx, "example.com", "_acme-challenge", "token-value"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []received{
+		{body: presentBody, contentType: "application/json", authorization: wantAuthHeader, signature: presentSig},
+		{body: cleanupBody, contentType: "application/json", authorization: wantAuthHeader, signature: cleanupSig},
+	}
+	if len(*reqs) != len(want) {
+		t.Fatalf("received %d requests, want %d", len(*reqs), len(want))
+	}
+	for i, got := range *reqs {
+		if got != want[i] {
+			t.Errorf("request %d:\n got %+v\nwant %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestUnsignedRequest(t *testing.T) {
+	reqs, url := newReceiver(t)
+	c, err := NewDNSClient(url, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.AddTXTRecord(context.Background(), "acme.example.net", "@", "v"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*reqs) != 1 {
+		t.Fatalf("received %d requests, want 1", len(*reqs))
+	}
+	got := (*reqs)[0]
+	if got.signature != "" || got.authorization != "" {
+		t.Errorf("unexpected auth headers: %+v", got)
+	}
+	if want := `{"fqdn":"acme.example.net.","value":"v","action":"present"}`; got.body != want {
+		t.Errorf("body = %s, want %s", got.body, want)
+	}
+}
+
+func newRetryClient(t *testing.T, url string) *DNSClient {
+	t.Helper()
+	c, err := NewDNSClient(url, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.retryDelay = time.Millisecond
+	return c
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int
+		wantErr  string // 为空时应成功
+	}{
+		{"2xx", []int{http.StatusNoContent}, 1, ""},
+		{"5xx then 2xx", []int{http.StatusInternalServerError, http.StatusOK}, 2, ""},
+		{"4xx not retried", []int{http.StatusBadRequest}, 1, "HTTP 400"},
+		{"5xx gives up", []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK}, maxAttempts, "HTTP 502"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs, url := newReceiver(t, tt.statuses...)
+			err := newRetryClient(t, url).AddTXTRecord(context.Background(), "example.com", "_acme-challenge", "v")
+
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("err = %v, want %s", err, tt.wantErr)
+			}
+			if len(*reqs) != tt.attempts {
+				t.Errorf("attempts = %d, want %d", len(*reqs), tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newRetryClient(t, server.URL)
+	c.retryDelay = time.Hour
+	done := make(chan error, 1)
+	go func() { done <- c.AddTXTRecord(ctx, "example.com", "_acme-challenge", "v") }()
+
+	select {
+	case err := <-done:
+		// 取消可能发生在收到响应之前或之后，两种情况都不应重试
+		if err == nil || !strings.Contains(err.Error(), context.Canceled.Error()) && !strings.Contains(err.Error(), "HTTP 503") {
+			t.Errorf("err = %v, want cancelled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("send did not stop after cancel")
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
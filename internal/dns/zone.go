@@ -0,0 +1,97 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"certctl/pkg/domain"
+
+	"github.com/miekg/dns"
+	"golang.org/x/net/publicsuffix"
+)
+
+// zoneCache 通过 SOA 查询确定的 zone：记录名 → zone
+var zoneCache = struct {
+	sync.Mutex
+	zones map[string]string
+}{zones: map[string]string{}}
+
+// FindZone 确定 fqdn 所在的 zone（不带末尾的点）
+// 从 fqdn 开始逐级向上查询 SOA 记录，应答中带有同名 SOA 的即为 zone 顶点，
+// 可以识别托管为独立 zone 的子域名（如 dev.example.com）；
+// 解析器都无法访问时回退到公共后缀列表（Public Suffix List）计算的根域名，回退结果不缓存，
+// 避免临时的网络故障使常驻进程一直把独立托管的子域名记录写入上级 zone；ctx 取消时返回 ctx.Err()
+func FindZone(ctx context.Context, fqdn string) (string, error) {
+	name := strings.ToLower(strings.TrimSuffix(fqdn, "."))
+
+	zoneCache.Lock()
+	zone, ok := zoneCache.zones[name]
+	zoneCache.Unlock()
+	if ok {
+		return zone, nil
+	}
+
+	zone, ok = lookupZone(ctx, name)
+	// 取消导致的查询失败不能回退，否则会缓存错误的 zone
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if !ok {
+		return domain.Parse(name)
+	}
+
+	zoneCache.Lock()
+	zoneCache.zones[name] = zone
+	zoneCache.Unlock()
+	return zone, nil
+}
+
+// ResetZoneCache 清空已确定的 zone，用于重新加载配置（解析器可能已更改）
+func ResetZoneCache() {
+	zoneCache.Lock()
+	zoneCache.zones = map[string]string{}
+	zoneCache.Unlock()
+}
+
+// lookupZone 逐级查询 SOA，到达公共后缀时停止
+func lookupZone(ctx context.Context, name string) (string, bool) {
+	labels := strings.Split(name, ".")
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+		if suffix, _ := publicsuffix.PublicSuffix(candidate); suffix == candidate {
+			break
+		}
+
+		apex, answered := isZoneApex(ctx, candidate)
+		if !answered {
+			return "", false
+		}
+		if apex {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// isZoneApex 查询 candidate 的 SOA 记录，answered 为 false 表示所有解析器都没有应答
+func isZoneApex(ctx context.Context, candidate string) (apex, answered bool) {
+	for _, resolver := range Resolvers() {
+		r, err := query(ctx, dns.Fqdn(candidate), dns.TypeSOA, resolver, true)
+		if err != nil {
+			continue
+		}
+		if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+			continue
+		}
+
+		// 名称上有 CNAME 时应答中是 CNAME 链，不是 zone 顶点
+		for _, ans := range r.Answer {
+			if soa, ok := ans.(*dns.SOA); ok && strings.EqualFold(soa.Hdr.Name, dns.Fqdn(candidate)) {
+				return true, true
+			}
+		}
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,114 @@
+package hook
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Timeout 单个钩子命令的最长执行时间
+const Timeout = 5 * time.Minute
+
+// Stage 钩子执行阶段
+type Stage string
+
+const (
+	StagePre    Stage = "pre"    // 申请证书前
+	StagePost   Stage = "post"   // 申请结束后，无论成功与否
+	StageDeploy Stage = "deploy" // 证书保存成功后，每个证书文件执行一次
+)
+
+// Hooks 各阶段要执行的 shell 命令
+type Hooks struct {
+	Pre    string `json:"pre,omitempty"`
+	Post   string `json:"post,omitempty"`
+	Deploy string `json:"deploy,omitempty"`
+}
+
+// Command 返回某阶段的命令
+func (h Hooks) Command(stage Stage) string {
+	switch stage {
+	case StagePre:
+		return h.Pre
+	case StagePost:
+		return h.Post
+	case StageDeploy:
+		return h.Deploy
+	}
+	return ""
+}
+
+// IsEmpty 是否未配置任何钩子
+func (h Hooks) IsEmpty() bool {
+	return h.Pre == "" && h.Post == "" && h.Deploy == ""
+}
+
+// Env 传递给钩子的证书信息，未知的字段为空
+type Env struct {
+	Domain   string    // 证书名称
+	Domains  []string  // 证书包含的全部域名
+	CertPath string    // 证书文件路径
+	KeyPath  string    // 私钥文件路径
+	NotAfter time.Time // 证书到期时间
+}
+
+// environ 生成 CERTCTL_* 环境变量
+func (e Env) environ(stage Stage) []string {
+	env := []string{
+		"CERTCTL_HOOK=" + string(stage),
+		"CERTCTL_DOMAIN=" + e.Domain,
+		"CERTCTL_DOMAINS=" + strings.Join(e.Domains, ","),
+		"CERTCTL_CERT_PATH=" + e.CertPath,
+		"CERTCTL_KEY_PATH=" + e.KeyPath,
+	}
+	if !e.NotAfter.IsZero() {
+		env = append(env, "CERTCTL_NOT_AFTER="+e.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return env
+}
+
+// Run 通过系统 shell 执行钩子命令，失败时错误中包含命令输出
+func Run(stage Stage, command string, env Env) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), env.environ(stage)...)
+
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s hook `%s` 超时（%s）", stage, command, Timeout)
+	}
+	if err != nil {
+		output := strings.TrimSpace(string(out))
+		if output == "" {
+			return fmt.Errorf("%s hook `%s`: %v", stage, command, err)
+		}
+		return fmt.Errorf("%s hook `%s`: %v: %s", stage, command, err, output)
+	}
+	return nil
+}
+
+// RunAll 依次执行全局钩子和证书钩子，返回所有失败
+func RunAll(stage Stage, env Env, hooks ...Hooks) []error {
+	var errs []error
+	for _, h := range hooks {
+		command := h.Command(stage)
+		if command == "" {
+			continue
+		}
+		if err := Run(stage, command, env); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
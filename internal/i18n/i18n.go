@@ -51,8 +51,6 @@ var zhMessages = map[string]string{
 	// 提示
 	"prompt.domain":     "域名",
 	"prompt.email":      "邮箱",
-	"prompt.ali_key":    "阿里云 AccessKey ID",
-	"prompt.ali_secret": "阿里云 AccessKey Secret",
 	"prompt.dns_added":  "已添加 DNS记录?",
 
 	// 详情
@@ -61,7 +59,6 @@ var zhMessages = map[string]string{
 	"detail.email":        "邮箱",
 	"detail.config_dir":   "配置目录",
 	"detail.dns_mode":     "DNS模式",
-	"detail.dns_aliyun":   "阿里云自动验证",
 	"detail.dns_manual":   "手动验证",
 	"detail.env_staging":  "Let's Encrypt 测试环境",
 	"detail.env_prod":     "Let's Encrypt 生产环境",
@@ -70,8 +67,6 @@ var zhMessages = map[string]string{
 	// 进度
 	"progress.params_done":   "参数收集完成",
 	"progress.account_ok":    "账户加载成功",
-	"progress.aliyun_ready":     "阿里云 DNS 就绪",
-	"progress.tencentcloud_ready": "腾讯云 DNS 就绪",
 	"progress.manual_ready":  "手动验证模式就绪",
 	"progress.client_ready":  "ACME 客户端就绪",
 	"progress.dns_ok":        "DNS 记录已生效",
@@ -91,11 +86,8 @@ var zhMessages = map[string]string{
 	"error.domain_invalid":   "域名解析失败",
 	"error.domain_parse":     "解析域名失败: %v",
 	"error.email_empty":      "邮箱不能为空",
-	"error.ali_key_empty":    "阿里云 AccessKey 不完整",
 	"error.account_fail":     "账户初始化失败",
-	"error.aliyun_fail":      "阿里云 DNS 客户端创建失败",
 	"error.aliyun_create":    "创建阿里云DNS客户端失败: %v",
-	"error.tencentcloud_fail":   "腾讯云 DNS 客户端创建失败",
 	"error.tencentcloud_create": "创建腾讯云DNS客户端失败: %v",
 	"error.client_fail":      "ACME 客户端创建失败",
 	"error.client_create":    "创建ACME客户端失败: %v",
@@ -114,6 +106,9 @@ var zhMessages = map[string]string{
 	"error.cert_parse":       "解析证书失败",
 	"error.cert_parse_err":   "解析证书失败: %v",
 	"error.save_fail":        "证书保存失败",
+	"error.partial_issue":    "%s 证书签发失败: %v（已签发的证书已保存: %s）",
+	"error.partial_save":     "已签发的证书保存失败: %v",
+	"warn.partial_issue":     "%s 证书签发失败，已签发的证书已保存:",
 	"error.user_cancel":      "用户取消操作",
 	"error.ai_request":       "AI请求失败: %v",
 	"error.ai_parse":         "AI响应解析失败: %v",
@@ -125,13 +120,10 @@ var zhMessages = map[string]string{
 	"error.ai_disabled":      "AI未启用",
 
 	// 提示
-	"hint.domain_usage":      "请使用 -d 参数指定域名，如: certctl apply -d example.com",
+	"hint.domain_usage":      "请使用 -d 参数指定域名，如: certctl apply -d example.com --wildcard",
 	"hint.domain_format":     "请检查域名格式是否正确（如 example.com）",
 	"hint.email_usage":       "邮箱用于 Let's Encrypt 账户注册",
 	"hint.email_reminder":    "证书到期前会收到续期提醒邮件",
-	"hint.ali_key_param":     "请通过参数 --ali-key 和 --ali-secret 指定",
-	"hint.ali_key_env":       "或设置环境变量: ALICLOUD_ACCESS_KEY, ALICLOUD_SECRET_KEY",
-	"hint.ali_key_url":       "获取方式: https://ram.console.aliyun.com/manage/ak",
 	"hint.check_network":     "请检查网络连接",
 	"hint.china_blocked":     "如果在国内，Let's Encrypt 服务器可能被阻断",
 	"hint.dns_check":         "请检查 DNS 记录是否正确添加",
@@ -179,16 +171,10 @@ var zhMessages = map[string]string{
 	"ui.dns_config_title":    "厂商DNS配置",
 	"ui.no_dns_config":       "暂无已保存的 DNS 配置",
 	"ui.select_action":       "选择操作:",
-	"ui.add_aliyun":          "添加阿里云 DNS 配置",
-	"ui.add_tencentcloud":    "添加腾讯云 DNS 配置",
 	"ui.delete_config":       "删除已保存的配置",
 	"ui.back":                "返回",
 	"ui.config_name":         "配置名称 (如: 公司账号)",
 	"ui.name_empty":          "配置名称不能为空",
-	"ui.ali_key_id":          "阿里云 AccessKey ID",
-	"ui.ali_key_empty":       "AccessKey ID 不能为空",
-	"ui.ali_secret":          "阿里云 AccessKey Secret",
-	"ui.ali_secret_empty":    "AccessKey Secret 不能为空",
 	"ui.config_saved":        "阿里云DNS配置「%s」已保存",
 	"ui.no_config_delete":    "没有可删除的配置",
 	"ui.select_delete":       "选择要删除的配置:",
@@ -226,9 +212,7 @@ var zhMessages = map[string]string{
 	"ui.email":               "邮箱",
 	"ui.email_empty":         "邮箱不能为空",
 	"ui.dns_verify_method":   "DNS验证方式:",
-	"ui.aliyun_auto":         "阿里云NS(自动)",
 	"ui.manual_dns":          "手动添加DNS记录",
-	"ui.select_aliyun_cfg":   "选择阿里云配置:",
 	"ui.input_new_config":    "输入新的配置",
 	"ui.using_config":        "使用配置「%s」",
 	"ui.save_config_local":   "是否保存此配置到本地?",
@@ -236,7 +220,6 @@ var zhMessages = map[string]string{
 	"ui.will_apply":          "将申请以下证书:",
 	"ui.domain_info":         "域名: %s, *.%s",
 	"ui.email_info":          "邮箱: %s",
-	"ui.dns_aliyun_auto":     "DNS: 阿里云自动验证",
 	"ui.dns_manual":          "DNS: 手动验证",
 	"ui.confirm_apply":       "确认申请?",
 	"ui.cancelled_op":        "已取消",
@@ -256,6 +239,201 @@ var zhMessages = map[string]string{
 
 	// 其他
 	"ui.press_enter":         "按 Enter 键返回主菜单...",
+
+	// CA
+	"detail.ca":           "CA",
+	"error.ca_url_empty":  "CA 目录地址不能为空",
+	"error.ca_invalid":    "CA 配置无效",
+	"error.meta_save":     "保存证书元数据失败: %v",
+	"hint.ca_list":        "使用 certctl ca list 查看可用的 CA",
+	"ca.builtin":          "内置 CA:",
+	"ca.custom":           "自定义 CA:",
+	"ca.name_builtin":     "「%s」是内置 CA 名称，请换一个名称",
+	"ca.url_invalid":      "无效的 CA 目录地址: %s",
+	"ca.saved":            "CA「%s」已保存",
+	"ca.not_found":        "未找到 CA: %s",
+	"ca.no_staging":       "CA「%s」没有内置的测试环境，不能与 --staging 同时使用",
+	"ca.staging_url":      "--staging 不能与 --ca-url 同时使用，请直接指定测试环境的目录地址",
+
+	// EAB
+	"error.eab_incomplete":  "EAB Key ID 和 HMAC Key 需要同时提供",
+	"error.eab_required":    "CA %s 要求 External Account Binding，请提供 EAB 凭证",
+	"hint.eab_usage":        "请通过 --eab-kid 和 --eab-hmac 指定 EAB 凭证，或使用 certctl ca add 保存",
+
+	// ACME 账户
+	"error.account_not_found":    "未找到账户 %s (CA: %s)",
+	"error.account_key":          "无法解析账户私钥: %s",
+	"error.account_ca_mismatch":  "账户 %s 属于 CA %s，不能用于 %s",
+	"error.account_legacy":       "无法读取旧版账户 %s，请检查或手动删除该文件及 account.key: %v",
+	"account.title":              "已保存的 ACME 账户:",
+	"account.none":               "暂无已保存的账户",
+	"account.list_fail":          "读取账户失败: %v",
+	"account.status":             "状态",
+	"account.registered":         "已注册",
+	"account.unregistered":       "未注册",
+	"account.key_path":           "私钥",
+	"account.uri":                "账户地址",
+	"account.exists":             "账户已注册: %s",
+	"account.registering":        "正在注册账户...",
+	"account.created":            "账户注册成功: %s",
+	"account.save_fail":          "保存账户失败: %v",
+	"account.delete_confirm":     "确定删除本地账户 %s (%s)?",
+	"account.deleted":            "账户已删除",
+
+	// 私钥类型
+	"detail.key_type":         "私钥类型",
+	"error.key_type":          "不支持的私钥类型 %s，可选: %s",
+	"error.key_type_invalid":  "私钥类型无效",
+
+	// SAN 域名
+	"detail.cert_name":         "证书名称",
+	"error.cert_name_invalid":  "证书名称无效，不能包含路径分隔符或以 . 开头",
+	"ui.wildcard_confirm":      "同时申请通配符 (*.域名)?",
+	"ui.domain_info_wildcard":  "域名: %s（含通配符）",
+	"ui.domain_info_list":      "域名: %s",
+
+	// 吊销
+	"error.revoke":               "吊销证书失败: %v",
+	"error.revoke_reason":        "不支持的吊销原因 %s，可选: %v",
+	"error.revoke_unregistered":  "账户未在 CA 注册，无法吊销证书",
+	"error.revoke_key":           "解析证书私钥失败: %v",
+	"revoke.title":               "将吊销以下证书:",
+	"revoke.reason":              "吊销原因",
+	"revoke.confirm":             "吊销后证书立即失效且无法恢复，确定继续?",
+	"revoke.no_target":           "请指定要吊销的证书",
+	"revoke.usage":               "使用 -d 指定证书名称，或 --cert 指定 PEM 文件路径",
+	"revoke.scan_fail":           "扫描证书失败: %v",
+	"revoke.not_found":           "在 %[2]s 中未找到证书 %[1]s",
+	"revoke.account_fail":        "加载签发账户失败",
+	"revoke.hint_cert_key":       "如果账户已丢失，可使用 --use-cert-key 以证书私钥认证",
+	"revoke.read_fail":           "读取 %s 失败: %v",
+	"revoke.fail":                "证书吊销失败",
+	"revoke.done":                "已吊销: %s",
+	"revoke.archive_fail":        "证书已吊销，但归档失败: %v",
+	"revoke.archived":            "证书文件已归档到: %s",
+	"revoke.key_unknown":         "无法确定 %s 对应的私钥，请通过 --key 指定",
+
+	// DNS 配置
+	"error.dns_config_not_found":  "未找到 DNS 配置「%s」",
+	"hint.dns_config":             "可在交互模式的「DNS 配置管理」中查看已保存的配置",
+	"hint.renew_dns_unsaved":      "DNS 凭证未保存，续期时需要重新提供（或使用 --dns-config 指定已保存的配置）",
+	"hint.renew_dns_env":          "续期时需要同样的环境变量: %s",
+
+	// 守护进程
+	"daemon.bad_interval":  "检查间隔无效: %s",
+	"daemon.started":       "守护进程已启动: 间隔 %s, 随机延迟 ≤ %s, 续期阈值 %d 天",
+	"daemon.stopping":      "收到信号 %s，正在退出",
+	"daemon.next":          "下一次检查将在 %s 后进行",
+	"daemon.checking":      "开始检查证书目录: %s",
+	"daemon.renewing":      "正在续期 %s",
+	"daemon.scan_fail":     "扫描证书失败: %v",
+	"daemon.renewed":       "%s 续期成功，有效期至 %s",
+	"daemon.failed":        "%s 续期失败: %v",
+	"daemon.summary":       "检查完成: 共 %d 个证书, 续期 %d, 失败 %d, 取消 %d",
+	"daemon.reloaded":      "已重新加载配置",
+
+	// 定时任务
+	"schedule.unsupported":        "Windows 暂不支持定时任务，请使用任务计划程序执行 certctl renew --all",
+	"schedule.choose":             "请指定 --systemd 或 --cron 其中之一",
+	"schedule.exe_fail":           "获取 certctl 路径失败: %v",
+	"schedule.install_fail":       "安装定时任务失败: %v",
+	"schedule.installed_systemd":  "已安装并启用 %s",
+	"schedule.linger_hint":        "用户级 timer 需要用户登录时才会运行，如需常驻请执行: loginctl enable-linger $USER",
+	"schedule.installed_cron":     "已写入 crontab",
+	"schedule.status_fail":        "查询失败: %v",
+	"schedule.not_installed":      "未安装",
+	"schedule.installed":          "已安装",
+	"schedule.remove_fail":        "删除定时任务失败: %v",
+	"schedule.removed_systemd":    "已删除 systemd timer",
+	"schedule.removed_cron":       "已删除 crontab 条目",
+
+	// 钩子
+	"hook.pre_fail":  "pre-hook 执行失败，已取消申请",
+	"hook.fail":      "钩子执行失败: %v",
+
+	// Cloudflare
+	"error.cloudflare_create":    "创建 Cloudflare DNS 客户端失败: %v",
+	"error.cloudflare_zone":      "Cloudflare 中未找到域名 %s 的 Zone，请确认 Token 有该 Zone 的 Zone:Read 权限",
+
+	// DNS 提供商
+	"dns.aliyun":               "阿里云",
+	"dns.tencentcloud":         "腾讯云",
+	"dns.cloudflare":           "Cloudflare",
+	"error.dns_credentials":    "缺少 %s 凭证: %s",
+	"error.dns_unsupported":    "不支持的 DNS 提供商: %s（支持: %s）",
+	"error.dns_provider_fail":  "%s DNS 客户端创建失败",
+	"detail.dns_auto":          "%s 自动验证",
+	"progress.dns_ready":       "%s DNS 就绪",
+	"hint.dns_flags":           "请通过参数 %s 指定",
+	"hint.dns_env":             "或设置环境变量: %s",
+	"hint.dns_url":             "获取方式: %s",
+	"ui.field_empty":           "%s 不能为空",
+	"ui.select_dns_cfg":        "选择%s配置:",
+	"ui.add_dns_config":        "添加%s DNS 配置",
+	"ui.dns_auto":              "%s(自动)",
+	"ui.dns_provider_auto":     "DNS: %s 自动验证",
+
+	// RFC 2136
+	"dns.rfc2136":              "RFC 2136",
+	"error.rfc2136_create":     "创建 RFC 2136 客户端失败: %v",
+	"error.rfc2136_algorithm":  "不支持的 TSIG 算法: %s（支持 hmac-md5/hmac-sha1/hmac-sha224/hmac-sha256/hmac-sha384/hmac-sha512）",
+
+	// CNAME 委托
+	"info.dns_delegated":  "验证记录已通过 CNAME 委托: %s → %s",
+	"delegate.usage":      "请指定域名和委托 zone",
+	"delegate.intro":      "请在域名当前的 DNS 服务商处添加以下 CNAME 记录:",
+	"delegate.name":       "记录名",
+	"delegate.type":       "类型",
+	"delegate.target":     "记录值",
+	"delegate.status":     "状态",
+	"delegate.ok":         "✔ 已生效",
+	"delegate.missing":    "✘ 未找到",
+	"delegate.other":      "⚠ 指向 %s",
+	"delegate.next":       "记录生效后，使用 %s 所在 DNS 的凭证申请证书，TXT 记录会写入委托目标",
+
+	// 脚本 DNS
+	"dns.exec":            "自定义脚本 (exec)",
+	"error.exec_create":   "创建脚本 DNS 提供者失败: %v",
+	"error.exec_timeout":  "脚本执行超时（%s）",
+	"error.exec_fail":     "DNS 脚本执行失败: %s",
+
+	// Webhook DNS
+	"dns.webhook":           "HTTP Webhook",
+	"error.webhook_create":  "创建 Webhook DNS 提供者失败: %v",
+
+	// 华为云
+	"dns.huaweicloud":           "华为云 DNS",
+	"error.huaweicloud_create":  "创建华为云 DNS 客户端失败: %v",
+	"error.huaweicloud_zone":    "华为云中未找到公网域名 %s，请确认 AK/SK 所属账号和项目有该域名的权限",
+
+	// DNS 传播检查
+	"error.dns_resolver":  "无效的 DNS 解析器地址: %s",
+	"error.dns_duration":  "无效的时长: %v",
+
+	// DNS 传播检查结果
+	"propagation.ok":             "✔ 已生效",
+	"propagation.mismatch":       "✘ 值不匹配",
+	"propagation.empty":          "✘ 无 TXT 记录",
+	"propagation.nxdomain":       "✘ NXDOMAIN",
+	"propagation.error":          "⚠ 查询失败",
+	"propagation.authoritative":  "权威",
+	"propagation.recursive":      "递归",
+	"propagation.title":          "DNS 传播检查结果: %s",
+	"propagation.server":         "服务器",
+	"propagation.kind":           "类型",
+	"propagation.status":         "结果",
+	"propagation.latency":        "耗时",
+	"propagation.values":         "返回值",
+	"propagation.unreachable":    "无法访问的权威服务器: %s，改为结合递归解析器的结果判断",
+
+	// 中断
+	"error.interrupted":       "操作已取消",
+	"interrupt.cancelled":     "已中断，本次申请已取消",
+	"interrupt.cleaned":       "已清理验证记录: %s",
+	"interrupt.cleanup_fail":  "以下验证记录清理失败，请手动删除",
+	"interrupt.manual":        "已添加的 TXT 记录可以手动删除",
+	"daemon.cleanup_fail":     "验证记录清理失败，请手动删除: %v",
+	"daemon.cancelled":        "%s 未续期: 检查被中断",
 }
 
 // 英文消息
@@ -271,8 +449,6 @@ var enMessages = map[string]string{
 	// Prompts
 	"prompt.domain":     "Domain",
 	"prompt.email":      "Email",
-	"prompt.ali_key":    "Aliyun AccessKey ID",
-	"prompt.ali_secret": "Aliyun AccessKey Secret",
 	"prompt.dns_added":  "DNS record added?",
 
 	// Details
@@ -281,7 +457,6 @@ var enMessages = map[string]string{
 	"detail.email":        "Email",
 	"detail.config_dir":   "Config dir",
 	"detail.dns_mode":     "DNS mode",
-	"detail.dns_aliyun":   "Aliyun auto",
 	"detail.dns_manual":   "Manual",
 	"detail.env_staging":  "Let's Encrypt Staging",
 	"detail.env_prod":     "Let's Encrypt Production",
@@ -290,8 +465,6 @@ var enMessages = map[string]string{
 	// Progress
 	"progress.params_done":   "Parameters collected",
 	"progress.account_ok":    "Account loaded",
-	"progress.aliyun_ready":     "Aliyun DNS ready",
-	"progress.tencentcloud_ready": "Tencent Cloud DNS ready",
 	"progress.manual_ready":  "Manual mode ready",
 	"progress.client_ready":  "ACME client ready",
 	"progress.dns_ok":        "DNS record verified",
@@ -311,11 +484,8 @@ var enMessages = map[string]string{
 	"error.domain_invalid":   "Invalid domain format",
 	"error.domain_parse":     "Failed to parse domain: %v",
 	"error.email_empty":      "Email cannot be empty",
-	"error.ali_key_empty":    "Aliyun AccessKey incomplete",
 	"error.account_fail":     "Account initialization failed",
-	"error.aliyun_fail":         "Aliyun DNS client creation failed",
 	"error.aliyun_create":       "Failed to create Aliyun DNS client: %v",
-	"error.tencentcloud_fail":   "Tencent Cloud DNS client creation failed",
 	"error.tencentcloud_create": "Failed to create Tencent Cloud DNS client: %v",
 	"error.client_fail":      "ACME client creation failed",
 	"error.client_create":    "Failed to create ACME client: %v",
@@ -334,6 +504,9 @@ var enMessages = map[string]string{
 	"error.cert_parse":       "Failed to parse certificate",
 	"error.cert_parse_err":   "Failed to parse certificate: %v",
 	"error.save_fail":        "Certificate save failed",
+	"error.partial_issue":    "%s certificate failed: %v (issued certificates were saved: %s)",
+	"error.partial_save":     "Failed to save the issued certificates: %v",
+	"warn.partial_issue":     "%s certificate failed, the issued certificates were saved:",
 	"error.user_cancel":      "User cancelled",
 	"error.ai_request":       "AI request failed: %v",
 	"error.ai_parse":         "AI response parse failed: %v",
@@ -345,13 +518,10 @@ var enMessages = map[string]string{
 	"error.ai_disabled":      "AI not enabled",
 
 	// Hints
-	"hint.domain_usage":      "Use -d to specify domain, e.g.: certctl apply -d example.com",
+	"hint.domain_usage":      "Use -d to specify domain, e.g.: certctl apply -d example.com --wildcard",
 	"hint.domain_format":     "Check domain format (e.g. example.com)",
 	"hint.email_usage":       "Email is used for Let's Encrypt account registration",
 	"hint.email_reminder":    "You will receive renewal reminders before expiry",
-	"hint.ali_key_param":     "Use --ali-key and --ali-secret parameters",
-	"hint.ali_key_env":       "Or set env: ALICLOUD_ACCESS_KEY, ALICLOUD_SECRET_KEY",
-	"hint.ali_key_url":       "Get keys at: https://ram.console.aliyun.com/manage/ak",
 	"hint.check_network":     "Check your network connection",
 	"hint.china_blocked":     "If in China, Let's Encrypt servers may be blocked",
 	"hint.dns_check":         "Verify DNS record is correctly added",
@@ -399,16 +569,10 @@ var enMessages = map[string]string{
 	"ui.dns_config_title":    "Vendor DNS Config",
 	"ui.no_dns_config":       "No saved DNS configs",
 	"ui.select_action":       "Select action:",
-	"ui.add_aliyun":          "Add Aliyun DNS Config",
-	"ui.add_tencentcloud":    "Add Tencent Cloud DNS Config",
 	"ui.delete_config":       "Delete Saved Config",
 	"ui.back":                "Back",
 	"ui.config_name":         "Config Name (e.g. Company)",
 	"ui.name_empty":          "Config name cannot be empty",
-	"ui.ali_key_id":          "Aliyun AccessKey ID",
-	"ui.ali_key_empty":       "AccessKey ID cannot be empty",
-	"ui.ali_secret":          "Aliyun AccessKey Secret",
-	"ui.ali_secret_empty":    "AccessKey Secret cannot be empty",
 	"ui.config_saved":        "Aliyun DNS config [%s] saved",
 	"ui.no_config_delete":    "No config to delete",
 	"ui.select_delete":       "Select config to delete:",
@@ -446,9 +610,7 @@ var enMessages = map[string]string{
 	"ui.email":               "Email",
 	"ui.email_empty":         "Email cannot be empty",
 	"ui.dns_verify_method":   "DNS Verification Method:",
-	"ui.aliyun_auto":         "Aliyun DNS (Auto)",
 	"ui.manual_dns":          "Manual DNS Record",
-	"ui.select_aliyun_cfg":   "Select Aliyun config:",
 	"ui.input_new_config":    "Input new config",
 	"ui.using_config":        "Using config [%s]",
 	"ui.save_config_local":   "Save this config locally?",
@@ -456,7 +618,6 @@ var enMessages = map[string]string{
 	"ui.will_apply":          "Will apply for:",
 	"ui.domain_info":         "Domain: %s, *.%s",
 	"ui.email_info":          "Email: %s",
-	"ui.dns_aliyun_auto":     "DNS: Aliyun Auto",
 	"ui.dns_manual":          "DNS: Manual",
 	"ui.confirm_apply":       "Confirm apply?",
 	"ui.cancelled_op":        "Cancelled",
@@ -476,4 +637,199 @@ var enMessages = map[string]string{
 
 	// Other
 	"ui.press_enter":         "Press Enter to return...",
+
+	// CA
+	"detail.ca":           "CA",
+	"error.ca_url_empty":  "CA directory URL cannot be empty",
+	"error.ca_invalid":    "Invalid CA configuration",
+	"error.meta_save":     "Failed to save certificate metadata: %v",
+	"hint.ca_list":        "Run certctl ca list to see available CAs",
+	"ca.builtin":          "Built-in CAs:",
+	"ca.custom":           "Custom CAs:",
+	"ca.name_builtin":     "[%s] is a built-in CA name, please choose another",
+	"ca.url_invalid":      "Invalid CA directory URL: %s",
+	"ca.saved":            "CA [%s] saved",
+	"ca.not_found":        "CA not found: %s",
+	"ca.no_staging":       "CA [%s] has no built-in staging environment and cannot be used with --staging",
+	"ca.staging_url":      "--staging cannot be used with --ca-url, pass the staging directory URL instead",
+
+	// EAB
+	"error.eab_incomplete":  "EAB key ID and HMAC key must be provided together",
+	"error.eab_required":    "CA %s requires External Account Binding, please provide EAB credentials",
+	"hint.eab_usage":        "Use --eab-kid and --eab-hmac, or save them with certctl ca add",
+
+	// ACME accounts
+	"error.account_not_found":    "Account %s not found (CA: %s)",
+	"error.account_key":          "Failed to parse account key: %s",
+	"error.account_ca_mismatch":  "Account %s belongs to CA %s and cannot be used with %s",
+	"error.account_legacy":       "Failed to read legacy account %s; check it or remove it together with account.key: %v",
+	"account.title":              "Saved ACME accounts:",
+	"account.none":               "No saved accounts",
+	"account.list_fail":          "Failed to read accounts: %v",
+	"account.status":             "Status",
+	"account.registered":         "Registered",
+	"account.unregistered":       "Not registered",
+	"account.key_path":           "Key",
+	"account.uri":                "Account URI",
+	"account.exists":             "Account already registered: %s",
+	"account.registering":        "Registering account...",
+	"account.created":            "Account registered: %s",
+	"account.save_fail":          "Failed to save account: %v",
+	"account.delete_confirm":     "Delete local account %s (%s)?",
+	"account.deleted":            "Account deleted",
+
+	// Key type
+	"detail.key_type":         "Key type",
+	"error.key_type":          "Unsupported key type %s, available: %s",
+	"error.key_type_invalid":  "Invalid key type",
+
+	// SAN domains
+	"detail.cert_name":         "Certificate name",
+	"error.cert_name_invalid":  "Invalid certificate name: must not contain path separators or start with .",
+	"ui.wildcard_confirm":      "Also request wildcard (*.domain)?",
+	"ui.domain_info_wildcard":  "Domains: %s (with wildcard)",
+	"ui.domain_info_list":      "Domains: %s",
+
+	// Revoke
+	"error.revoke":               "Failed to revoke certificate: %v",
+	"error.revoke_reason":        "Unsupported revocation reason %s, available: %v",
+	"error.revoke_unregistered":  "Account is not registered with the CA",
+	"error.revoke_key":           "Failed to parse certificate key: %v",
+	"revoke.title":               "Will revoke:",
+	"revoke.reason":              "Reason",
+	"revoke.confirm":             "Revocation is immediate and irreversible. Continue?",
+	"revoke.no_target":           "Please specify a certificate to revoke",
+	"revoke.usage":               "Use -d for a certificate name or --cert for a PEM path",
+	"revoke.scan_fail":           "Failed to scan certificates: %v",
+	"revoke.not_found":           "Certificate %s not found in %s",
+	"revoke.account_fail":        "Failed to load issuing account",
+	"revoke.hint_cert_key":       "If the account is lost, use --use-cert-key to authenticate with the certificate key",
+	"revoke.read_fail":           "Failed to read %s: %v",
+	"revoke.fail":                "Certificate revocation failed",
+	"revoke.done":                "Revoked: %s",
+	"revoke.archive_fail":        "Certificate revoked but archiving failed: %v",
+	"revoke.archived":            "Certificate files archived to: %s",
+	"revoke.key_unknown":         "Cannot determine the private key for %s, please specify it with --key",
+
+	// DNS config
+	"error.dns_config_not_found":  "DNS config \"%s\" not found",
+	"hint.dns_config":             "Saved configs are listed under \"DNS Config\" in interactive mode",
+	"hint.renew_dns_unsaved":      "DNS credentials were not saved, renewals need them again (or use --dns-config with a saved config)",
+	"hint.renew_dns_env":          "Renewals need the same environment variables: %s",
+
+	// Daemon
+	"daemon.bad_interval":  "invalid interval: %s",
+	"daemon.started":       "Daemon started: interval %s, jitter up to %s, renew threshold %d days",
+	"daemon.stopping":      "Received %s, shutting down",
+	"daemon.next":          "Next check in %s",
+	"daemon.checking":      "Checking certificates in %s",
+	"daemon.renewing":      "Renewing %s",
+	"daemon.scan_fail":     "Failed to scan certificates: %v",
+	"daemon.renewed":       "%s renewed, valid until %s",
+	"daemon.failed":        "%s renewal failed: %v",
+	"daemon.summary":       "Check finished: %d certificates, %d renewed, %d failed, %d cancelled",
+	"daemon.reloaded":      "Configuration reloaded",
+
+	// Schedule
+	"schedule.unsupported":        "Scheduling is not supported on Windows, use Task Scheduler to run certctl renew --all",
+	"schedule.choose":             "Specify exactly one of --systemd or --cron",
+	"schedule.exe_fail":           "Failed to locate certctl binary: %v",
+	"schedule.install_fail":       "Failed to install schedule: %v",
+	"schedule.installed_systemd":  "Installed and enabled %s",
+	"schedule.linger_hint":        "User timers only run while you are logged in; run loginctl enable-linger $USER to keep them active",
+	"schedule.installed_cron":     "Crontab entry installed",
+	"schedule.status_fail":        "query failed: %v",
+	"schedule.not_installed":      "not installed",
+	"schedule.installed":          "installed",
+	"schedule.remove_fail":        "Failed to remove schedule: %v",
+	"schedule.removed_systemd":    "systemd timer removed",
+	"schedule.removed_cron":       "Crontab entry removed",
+
+	// Hooks
+	"hook.pre_fail":  "pre-hook failed, request cancelled",
+	"hook.fail":      "Hook failed: %v",
+
+	// Cloudflare
+	"error.cloudflare_create":    "Failed to create Cloudflare DNS client: %v",
+	"error.cloudflare_zone":      "No Cloudflare zone found for %s, make sure the token has Zone:Read on it",
+
+	// DNS providers
+	"dns.aliyun":               "Aliyun",
+	"dns.tencentcloud":         "Tencent Cloud",
+	"dns.cloudflare":           "Cloudflare",
+	"error.dns_credentials":    "Missing %s credentials: %s",
+	"error.dns_unsupported":    "Unsupported DNS provider: %s (supported: %s)",
+	"error.dns_provider_fail":  "%s DNS client creation failed",
+	"detail.dns_auto":          "%s auto",
+	"progress.dns_ready":       "%s DNS ready",
+	"hint.dns_flags":           "Pass them with %s",
+	"hint.dns_env":             "Or set environment variables: %s",
+	"hint.dns_url":             "Get credentials at: %s",
+	"ui.field_empty":           "%s cannot be empty",
+	"ui.select_dns_cfg":        "Select %s config:",
+	"ui.add_dns_config":        "Add %s DNS Config",
+	"ui.dns_auto":              "%s (Auto)",
+	"ui.dns_provider_auto":     "DNS: %s Auto",
+
+	// RFC 2136
+	"dns.rfc2136":              "RFC 2136",
+	"error.rfc2136_create":     "Failed to create RFC 2136 client: %v",
+	"error.rfc2136_algorithm":  "Unsupported TSIG algorithm: %s (supported: hmac-md5/hmac-sha1/hmac-sha224/hmac-sha256/hmac-sha384/hmac-sha512)",
+
+	// CNAME delegation
+	"info.dns_delegated":  "Challenge record is delegated via CNAME: %s → %s",
+	"delegate.usage":      "Please specify domains and the delegation zone",
+	"delegate.intro":      "Add the following CNAME records at the domain's current DNS provider:",
+	"delegate.name":       "Name",
+	"delegate.type":       "Type",
+	"delegate.target":     "Target",
+	"delegate.status":     "Status",
+	"delegate.ok":         "✔ active",
+	"delegate.missing":    "✘ not found",
+	"delegate.other":      "⚠ points to %s",
+	"delegate.next":       "Once active, issue certificates with credentials for the DNS hosting %s; TXT records will be written to the delegation target",
+
+	// Exec DNS
+	"dns.exec":            "Custom script (exec)",
+	"error.exec_create":   "Failed to create exec DNS provider: %v",
+	"error.exec_timeout":  "script timed out (%s)",
+	"error.exec_fail":     "DNS script failed: %s",
+
+	// Webhook DNS
+	"dns.webhook":           "HTTP Webhook",
+	"error.webhook_create":  "Failed to create webhook DNS provider: %v",
+
+	// Huawei Cloud
+	"dns.huaweicloud":           "Huawei Cloud DNS",
+	"error.huaweicloud_create":  "Failed to create Huawei Cloud DNS client: %v",
+	"error.huaweicloud_zone":    "No Huawei Cloud public zone found for %s, make sure the AK/SK account and project can access it",
+
+	// DNS propagation
+	"error.dns_resolver":  "Invalid DNS resolver address: %s",
+	"error.dns_duration":  "Invalid duration: %v",
+
+	// DNS propagation report
+	"propagation.ok":             "✔ propagated",
+	"propagation.mismatch":       "✘ wrong value",
+	"propagation.empty":          "✘ no TXT record",
+	"propagation.nxdomain":       "✘ NXDOMAIN",
+	"propagation.error":          "⚠ query failed",
+	"propagation.authoritative":  "authoritative",
+	"propagation.recursive":      "recursive",
+	"propagation.title":          "DNS propagation report: %s",
+	"propagation.server":         "Server",
+	"propagation.kind":           "Type",
+	"propagation.status":         "Result",
+	"propagation.latency":        "Latency",
+	"propagation.values":         "Returned",
+	"propagation.unreachable":    "Unreachable authoritative servers: %s, recursive resolvers were checked instead",
+
+	// Interrupt
+	"error.interrupted":       "Operation cancelled",
+	"interrupt.cancelled":     "Interrupted, the request was cancelled",
+	"interrupt.cleaned":       "Removed challenge records: %s",
+	"interrupt.cleanup_fail":  "Failed to remove the following challenge records, please delete them manually",
+	"interrupt.manual":        "You can now delete the TXT records you added",
+	"daemon.cleanup_fail":     "Failed to remove challenge record, please delete it manually: %v",
+	"daemon.cancelled":        "%s not renewed: check was interrupted",
 }
@@ -0,0 +1,98 @@
+package schedule
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// cronMarker 标记 certctl 写入的 crontab 行，用于更新和删除
+const cronMarker = "# " + Name
+
+// CronLine 生成 crontab 行，每天 03:00 执行
+func CronLine(opts Options) string {
+	command := fmt.Sprintf("HOME=%s %s", quoteArgs([]string{opts.Home}), quoteArgs(opts.Args()))
+	// crontab 中未转义的 % 会被当作换行
+	command = strings.ReplaceAll(command, "%", `\%`)
+	return fmt.Sprintf("0 3 * * * %s %s", command, cronMarker)
+}
+
+// readCrontab 读取当前用户的 crontab，没有 crontab 时返回空
+func readCrontab() (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("crontab", "-l")
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "no crontab") {
+			return "", nil
+		}
+		return "", fmt.Errorf("crontab -l: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// writeCrontab 覆盖当前用户的 crontab
+func writeCrontab(content string) error {
+	cmd := exec.Command("crontab", "-")
+	cmd.Stdin = strings.NewReader(content)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("crontab -: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// splitCrontab 拆分出 certctl 写入的行和其他行
+func splitCrontab(content string) (ours, others []string) {
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		if line == "" && len(others) == 0 {
+			continue
+		}
+		if strings.HasSuffix(strings.TrimSpace(line), cronMarker) {
+			ours = append(ours, line)
+		} else {
+			others = append(others, line)
+		}
+	}
+	return ours, others
+}
+
+// InstallCron 写入 crontab，已存在的 certctl 行会被替换
+func InstallCron(opts Options) (string, error) {
+	content, err := readCrontab()
+	if err != nil {
+		return "", err
+	}
+	_, others := splitCrontab(content)
+	line := CronLine(opts)
+	lines := append(others, line)
+	return line, writeCrontab(strings.Join(lines, "\n") + "\n")
+}
+
+// RemoveCron 删除 certctl 写入的 crontab 行，返回删除的行数
+func RemoveCron() (int, error) {
+	content, err := readCrontab()
+	if err != nil {
+		return 0, err
+	}
+	ours, others := splitCrontab(content)
+	if len(ours) == 0 {
+		return 0, nil
+	}
+	newContent := ""
+	if len(others) > 0 {
+		newContent = strings.Join(others, "\n") + "\n"
+	}
+	return len(ours), writeCrontab(newContent)
+}
+
+// CronStatus 查询 crontab 中的 certctl 行
+func CronStatus() (Status, error) {
+	content, err := readCrontab()
+	if err != nil {
+		return Status{}, err
+	}
+	ours, _ := splitCrontab(content)
+	return Status{Installed: len(ours) > 0, Detail: strings.Join(ours, "\n")}, nil
+}
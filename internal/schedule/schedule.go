@@ -0,0 +1,45 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Name 定时任务名称，用于 systemd 单元名和 crontab 标记
+const Name = "certctl-renew"
+
+// Options 定时续期参数
+type Options struct {
+	Binary   string // certctl 可执行文件的绝对路径
+	Home     string // 运行用户的主目录，certctl 从 $HOME/.certctl 读取配置
+	CertsDir string // 证书目录，为空时使用配置中的目录
+	Days     int    // 剩余天数少于该值时续期
+}
+
+// Args 返回续期命令的参数
+func (o Options) Args() []string {
+	args := []string{o.Binary, "renew", "--all", "--days", fmt.Sprintf("%d", o.Days)}
+	if o.CertsDir != "" {
+		args = append(args, "--output", o.CertsDir)
+	}
+	return args
+}
+
+// Status 定时任务安装状态
+type Status struct {
+	Installed bool
+	Files     []string // 已安装的文件，crontab 方式为空
+	Detail    string   // systemctl / crontab 的输出
+}
+
+// quoteArgs 为含空格或特殊字符的参数加引号
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\"'\\$%;&|<>*?") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,153 @@
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// SystemdDir 返回单元文件目录：root 使用系统目录，普通用户使用用户目录
+func SystemdDir(user bool) (string, error) {
+	if !user {
+		return "/etc/systemd/system", nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "systemd", "user"), nil
+}
+
+// SystemdUnits 生成 service 和 timer 单元内容
+// 每天 03:00 左右触发，随机延迟 1 小时，错过的触发会在开机后补上
+func SystemdUnits(opts Options) (service, timer string) {
+	args := opts.Args()
+	for i, a := range args {
+		// systemd 使用双引号，并将 % 视为说明符
+		a = strings.ReplaceAll(a, "%", "%%")
+		if strings.ContainsAny(a, " \t\"'\\") {
+			a = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(a) + `"`
+		}
+		args[i] = a
+	}
+
+	service = fmt.Sprintf(`[Unit]
+Description=Renew SSL certificates with certctl
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=oneshot
+Environment="HOME=%s"
+ExecStart=%s
+`, opts.Home, strings.Join(args, " "))
+
+	timer = fmt.Sprintf(`[Unit]
+Description=Run %s daily
+
+[Timer]
+OnCalendar=*-*-* 03:00:00
+RandomizedDelaySec=1h
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, Name+".service")
+	return service, timer
+}
+
+// systemctl 执行 systemctl，用户模式下附加 --user
+func systemctl(user bool, args ...string) (string, error) {
+	if user {
+		args = append([]string{"--user"}, args...)
+	}
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("systemctl %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// InstallSystemd 写入单元文件并启用 timer，返回写入的文件
+func InstallSystemd(opts Options, user bool) ([]string, error) {
+	dir, err := SystemdDir(user)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	service, timer := SystemdUnits(opts)
+	servicePath := filepath.Join(dir, Name+".service")
+	timerPath := filepath.Join(dir, Name+".timer")
+	if err := os.WriteFile(servicePath, []byte(service), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(timerPath, []byte(timer), 0644); err != nil {
+		return nil, err
+	}
+	files := []string{servicePath, timerPath}
+
+	if _, err := systemctl(user, "daemon-reload"); err != nil {
+		return files, err
+	}
+	if _, err := systemctl(user, "enable", "--now", Name+".timer"); err != nil {
+		return files, err
+	}
+	return files, nil
+}
+
+// RemoveSystemd 停用 timer 并删除单元文件
+func RemoveSystemd(user bool) ([]string, error) {
+	dir, err := SystemdDir(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// 未安装时 disable 会失败，忽略即可
+	systemctl(user, "disable", "--now", Name+".timer")
+
+	var removed []string
+	for _, f := range []string{Name + ".timer", Name + ".service"} {
+		path := filepath.Join(dir, f)
+		if err := os.Remove(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+
+	if len(removed) > 0 {
+		if _, err := systemctl(user, "daemon-reload"); err != nil {
+			return removed, err
+		}
+	}
+	return removed, nil
+}
+
+// SystemdStatus 查询单元文件和 timer 状态
+func SystemdStatus(user bool) (Status, error) {
+	dir, err := SystemdDir(user)
+	if err != nil {
+		return Status{}, err
+	}
+
+	var status Status
+	for _, f := range []string{Name + ".service", Name + ".timer"} {
+		path := filepath.Join(dir, f)
+		if _, err := os.Stat(path); err == nil {
+			status.Files = append(status.Files, path)
+		}
+	}
+	status.Installed = len(status.Files) == 2
+	if status.Installed {
+		out, _ := systemctl(user, "list-timers", "--all", "--no-pager", Name+".timer")
+		status.Detail = strings.TrimSpace(out)
+	}
+	return status, nil
+}
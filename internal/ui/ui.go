@@ -213,6 +213,46 @@ func Box(title string, lines []string) {
 	fmt.Printf("  └%s┘\n", dimmed(strings.Repeat("─", width)))
 }
 
+// Table 输出对齐的表格，单元格可包含颜色
+func Table(headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = runewidth.StringWidth(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := runewidth.StringWidth(stripANSI(cell)); i < len(widths) && w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	printRow := func(cells []string, style func(a ...interface{}) string) {
+		fmt.Print("  ")
+		for i, cell := range cells {
+			if i >= len(widths) {
+				break
+			}
+			padding := widths[i] - runewidth.StringWidth(stripANSI(cell))
+			if padding < 0 || i == len(widths)-1 {
+				padding = 0
+			}
+			fmt.Print(style(cell) + strings.Repeat(" ", padding) + "  ")
+		}
+		fmt.Println()
+	}
+
+	printRow(headers, bold)
+	sep := make([]string, len(widths))
+	for i, w := range widths {
+		sep[i] = strings.Repeat("─", w)
+	}
+	printRow(sep, dimmed)
+	for _, row := range rows {
+		printRow(row, fmt.Sprint)
+	}
+}
+
 // AIBox 美化 AI 诊断输出
 func AIBox(content string) {
 	boxWidth := 68 // 内容区域宽度
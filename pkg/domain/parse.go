@@ -3,28 +3,22 @@ package domain
 import (
 	"errors"
 	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
 )
 
 var ErrInvalidDomain = errors.New("无效的域名格式")
 
-// 常见的二级域名后缀
-var secondLevelTLDs = map[string]bool{
-	"com.cn": true, "net.cn": true, "org.cn": true, "gov.cn": true,
-	"co.uk": true, "org.uk": true, "ac.uk": true,
-	"co.jp": true, "ne.jp": true, "or.jp": true,
-	"com.au": true, "net.au": true, "org.au": true,
-	"co.kr": true, "or.kr": true,
-	"com.hk": true, "org.hk": true,
-	"com.tw": true, "org.tw": true,
-}
+var ErrInvalidName = errors.New("无效的证书名称")
 
-// Parse 解析域名，返回根域名
-func Parse(domain string) (string, error) {
+// clean 去除协议、路径和端口，保留通配符前缀
+func clean(domain string) string {
 	domain = strings.ToLower(strings.TrimSpace(domain))
 	domain = strings.TrimPrefix(domain, "http://")
 	domain = strings.TrimPrefix(domain, "https://")
-	domain = strings.TrimPrefix(domain, "*.")
 	domain = strings.TrimSuffix(domain, "/")
+	domain = strings.TrimSuffix(domain, ".")
 
 	// 移除路径部分
 	if idx := strings.Index(domain, "/"); idx != -1 {
@@ -36,28 +30,51 @@ func Parse(domain string) (string, error) {
 		domain = domain[:idx]
 	}
 
-	if domain == "" {
-		return "", ErrInvalidDomain
-	}
+	return domain
+}
 
-	parts := strings.Split(domain, ".")
-	if len(parts) < 2 {
-		return "", ErrInvalidDomain
+// toASCII 将国际化域名（IDN）的各个标签转换为 A-label（Punycode），如 例子.中国 → xn--fsqu00a.xn--fiqs8s
+// 只转换包含非 ASCII 字符的标签，ASCII 标签原样保留（允许下划线）
+func toASCII(domain string) (string, error) {
+	// 中文输入法下常见的全角句号同样作为标签分隔符
+	domain = strings.NewReplacer("。", ".", "．", ".", "｡", ".").Replace(domain)
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		a, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return "", ErrInvalidDomain
+		}
+		labels[i] = a
 	}
+	return strings.Join(labels, "."), nil
+}
 
-	// 检查是否是二级域名后缀
-	if len(parts) >= 3 {
-		possibleTLD := parts[len(parts)-2] + "." + parts[len(parts)-1]
-		if secondLevelTLDs[possibleTLD] {
-			if len(parts) < 3 {
-				return "", ErrInvalidDomain
-			}
-			return parts[len(parts)-3] + "." + possibleTLD, nil
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
 		}
 	}
+	return true
+}
 
-	// 普通域名，返回最后两部分
-	return parts[len(parts)-2] + "." + parts[len(parts)-1], nil
+// Parse 解析域名，按公共后缀列表（Public Suffix List）返回可注册的根域名
+// 如 www.example.com.cn → example.com.cn，a.b.gov.uk → b.gov.uk
+func Parse(domain string) (string, error) {
+	domain, err := toASCII(strings.TrimPrefix(clean(domain), "*."))
+	if err != nil || domain == "" || !strings.Contains(domain, ".") {
+		return "", ErrInvalidDomain
+	}
+
+	// 域名本身就是公共后缀（如 com.cn）时返回错误
+	root, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return "", ErrInvalidDomain
+	}
+	return root, nil
 }
 
 // GenerateWildcard 生成通配符域名列表
@@ -69,3 +86,86 @@ func GenerateWildcard(domain string) ([]string, error) {
 
 	return []string{root, "*." + root}, nil
 }
+
+// Normalize 规范化单个域名，保留最左侧的通配符，如 *.dev.example.com
+// 国际化域名转换为 A-label，证书、目录名和 DNS 记录统一使用该形式
+func Normalize(domain string) (string, error) {
+	domain, err := toASCII(clean(domain))
+	if err != nil {
+		return "", err
+	}
+
+	host := strings.TrimPrefix(domain, "*.")
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 {
+		return "", ErrInvalidDomain
+	}
+	for _, label := range labels {
+		if !validLabel(label) {
+			return "", ErrInvalidDomain
+		}
+	}
+
+	return domain, nil
+}
+
+func validLabel(label string) bool {
+	if label == "" || len(label) > 63 {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildSANs 根据用户输入构建证书域名列表，按输入顺序去重
+// wildcard 为 true 时为每个非通配符域名追加对应的 *.domain
+func BuildSANs(inputs []string, wildcard bool) ([]string, error) {
+	var sans []string
+	seen := map[string]bool{}
+	add := func(d string) {
+		if !seen[d] {
+			seen[d] = true
+			sans = append(sans, d)
+		}
+	}
+
+	for _, input := range inputs {
+		d, err := Normalize(input)
+		if err != nil {
+			return nil, err
+		}
+		add(d)
+		if wildcard && !strings.HasPrefix(d, "*.") {
+			add("*." + d)
+		}
+	}
+
+	if len(sans) == 0 {
+		return nil, ErrInvalidDomain
+	}
+	return sans, nil
+}
+
+// CertName 返回证书默认名称（即证书目录名）：第一个域名去掉通配符前缀
+func CertName(sans []string) string {
+	if len(sans) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(sans[0], "*.")
+}
+
+// ValidateCertName 检查证书名称是否可以用作目录名
+func ValidateCertName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, ".") ||
+		strings.ContainsAny(name, "/\\:*?\"<>|") {
+		return ErrInvalidName
+	}
+	return nil
+}